@@ -2,6 +2,7 @@ package yahooapi
 
 import (
 	"encoding/gob"
+	"errors"
 	"github.com/gorilla/sessions"
 	"golang.org/x/oauth2"
 	"log"
@@ -13,6 +14,9 @@ type YahooConfig struct {
 	conf         *oauth2.Config
 	SessionStore sessions.Store
 	landing      string
+
+	// Cache, if set, is shared by the Clients of all users.
+	Cache Cache
 }
 
 func NewYahooConfig(clientID, clientSecret string, scopes []string, hostName string, landing string, sessionStore sessions.Store) *YahooConfig {
@@ -67,7 +71,8 @@ func (a *YahooConfig) AuthYahooCallback(w http.ResponseWriter, r *http.Request)
 
 	tok, err := a.conf.Exchange(oauth2.NoContext, code)
 	if err != nil {
-		log.Fatal(err)
+		http.Error(w, err.Error(), 500)
+		return
 	}
 	session.Values["token"] = *tok
 	session.Values["xoauth_yahoo_guid"] = r.FormValue("xoauth_yahoo_guid")
@@ -77,3 +82,23 @@ func (a *YahooConfig) AuthYahooCallback(w http.ResponseWriter, r *http.Request)
 
 	http.Redirect(w, r, a.landing, 302)
 }
+
+// Client returns a Client acting on behalf of the user whose token was
+// stored in r's session by AuthYahooCallback.
+func (a *YahooConfig) Client(r *http.Request) (*Client, error) {
+	session, err := a.SessionStore.Get(r, "session-name")
+	if err != nil {
+		return nil, err
+	}
+
+	tok, ok := session.Values["token"].(*oauth2.Token)
+	if !ok {
+		return nil, errors.New("error deserializing token from session")
+	}
+	ctx, rt := withRetryTransport(oauth2.NoContext)
+	c := NewClient(a.conf.Client(ctx, tok))
+	c.retry = rt
+	c.Cache = a.Cache
+	c.GUID, _ = session.Values["xoauth_yahoo_guid"].(string)
+	return c, nil
+}
@@ -0,0 +1,207 @@
+package yahooapi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// MaxKeysPerRequest is the largest number of keys Yahoo! accepts in the
+// player_keys or team_keys of a single request.
+const MaxKeysPerRequest = 25
+
+// DefaultBatchWorkers is the number of requests a batch has in flight at
+// once when Client.BatchWorkers is zero.
+const DefaultBatchWorkers = 4
+
+// ErrNotReturned is the error of a batch result whose key was accepted by
+// Yahoo! but whose resource was missing from the response.
+var ErrNotReturned = errors.New("yahooapi: resource missing from response")
+
+// PlayerResult is the outcome of fetching a single player in a batch.
+type PlayerResult struct {
+	Key    PlayerKey
+	Player *PlayerResource
+	Err    error
+}
+
+// TeamResult is the outcome of fetching a single team in a batch.
+type TeamResult struct {
+	Key  TeamKey
+	Team *TeamResource
+	Err  error
+}
+
+// Players fetches the players identified by keys, along with the
+// sub-resources named in out. Keys are requested MaxKeysPerRequest at a
+// time, with up to BatchWorkers requests in flight. The results are in the
+// same order as keys, and each carries its own error: when Yahoo! rejects
+// a request because one of its keys is unknown, its keys are retried one
+// at a time so that only the unknown key fails.
+func (c *Client) Players(ctx context.Context, keys []PlayerKey, out ...string) []PlayerResult {
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k.String()
+	}
+	build := func(keys []string) *Query {
+		q := Players().PlayerKeys(keys...)
+		if len(out) > 0 {
+			q = q.Out(out...)
+		}
+		return q
+	}
+	find := func(content *FantasyContent, key string) interface{} {
+		for i := range content.Players {
+			if sameKey(key, content.Players[i].PlayerKey) {
+				return &content.Players[i]
+			}
+		}
+		return nil
+	}
+
+	results := make([]PlayerResult, len(keys))
+	for i, r := range c.batch(ctx, s, build, find) {
+		results[i] = PlayerResult{Key: keys[i], Err: r.err}
+		if r.err == nil {
+			results[i].Player = r.v.(*PlayerResource)
+		}
+	}
+	return results
+}
+
+// Teams fetches the teams identified by keys, along with the sub-resources
+// named in out, in the same way as Players.
+func (c *Client) Teams(ctx context.Context, keys []TeamKey, out ...string) []TeamResult {
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k.String()
+	}
+	build := func(keys []string) *Query {
+		q := Teams().TeamKeys(keys...)
+		if len(out) > 0 {
+			q = q.Out(out...)
+		}
+		return q
+	}
+	find := func(content *FantasyContent, key string) interface{} {
+		for i := range content.Teams {
+			if sameKey(key, content.Teams[i].TeamKey) {
+				return &content.Teams[i]
+			}
+		}
+		return nil
+	}
+
+	results := make([]TeamResult, len(keys))
+	for i, r := range c.batch(ctx, s, build, find) {
+		results[i] = TeamResult{Key: keys[i], Err: r.err}
+		if r.err == nil {
+			results[i].Team = r.v.(*TeamResource)
+		}
+	}
+	return results
+}
+
+type batchResult struct {
+	v   interface{}
+	err error
+}
+
+// batch fetches keys in chunks of MaxKeysPerRequest, building the query
+// for each chunk with build and picking each key's resource out of the
+// response with find, which returns nil if it is missing. Duplicate keys
+// are only requested once.
+func (c *Client) batch(ctx context.Context, keys []string, build func([]string) *Query, find func(*FantasyContent, string) interface{}) []batchResult {
+	indexes := make(map[string][]int)
+	var unique []string
+	for i, k := range keys {
+		if _, ok := indexes[k]; !ok {
+			unique = append(unique, k)
+		}
+		indexes[k] = append(indexes[k], i)
+	}
+
+	var chunks [][]string
+	for len(unique) > 0 {
+		n := MaxKeysPerRequest
+		if n > len(unique) {
+			n = len(unique)
+		}
+		chunks = append(chunks, unique[:n])
+		unique = unique[n:]
+	}
+
+	workers := c.BatchWorkers
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+	if workers > len(chunks) {
+		workers = len(chunks)
+	}
+
+	results := make([]batchResult, len(keys))
+	work := make(chan []string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range work {
+				// Each key belongs to a single chunk, so workers never
+				// write to the same result.
+				for j, r := range c.batchChunk(ctx, chunk, build, find) {
+					for _, i := range indexes[chunk[j]] {
+						results[i] = r
+					}
+				}
+			}
+		}()
+	}
+	for _, chunk := range chunks {
+		work <- chunk
+	}
+	close(work)
+	wg.Wait()
+	return results
+}
+
+// batchChunk fetches a single chunk of keys.
+func (c *Client) batchChunk(ctx context.Context, keys []string, build func([]string) *Query, find func(*FantasyContent, string) interface{}) []batchResult {
+	results := make([]batchResult, len(keys))
+	if err := ctx.Err(); err != nil {
+		for i := range results {
+			results[i].err = err
+		}
+		return results
+	}
+
+	content, err := c.Execute(ctx, build(keys))
+	if err != nil && len(keys) > 1 && IsNotFound(err) {
+		for i := range keys {
+			results[i] = c.batchChunk(ctx, keys[i:i+1], build, find)[0]
+		}
+		return results
+	}
+	for i, k := range keys {
+		if err != nil {
+			results[i].err = err
+		} else if results[i].v = find(content, k); results[i].v == nil {
+			results[i].err = ErrNotReturned
+		}
+	}
+	return results
+}
+
+// sameKey reports whether the key returned by Yahoo! refers to the same
+// resource as the requested key. Yahoo! replaces game_codes with game_ids
+// in the keys it returns, so a requested nfl.p.5479 matches 257.p.5479.
+func sameKey(requested, returned string) bool {
+	if requested == returned {
+		return true
+	}
+	req := strings.SplitN(requested, ".", 2)
+	ret := strings.SplitN(returned, ".", 2)
+	return len(req) == 2 && len(ret) == 2 && req[1] == ret[1] && isCode(req[0]) && isNumber(ret[0])
+}
@@ -0,0 +1,221 @@
+package yahooapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+// batchServer serves players and teams collections for the keys requested
+// in player_keys or team_keys. Keys with id 404 do not exist and make
+// Yahoo! reject the whole request, keys with id 410 are accepted but left
+// out of the response, and game_codes are replaced with the game_id 257.
+type batchServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests [][]string
+}
+
+func newBatchServer() *batchServer {
+	s := &batchServer{}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+func (s *batchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	collection := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), ";", 2)[0]
+	resource := strings.TrimSuffix(collection, "s")
+	param := resource + "_keys="
+	i := strings.Index(r.URL.Path, param)
+	if i < 0 {
+		http.Error(w, "no keys", http.StatusBadRequest)
+		return
+	}
+	keys := r.URL.Path[i+len(param):]
+	if j := strings.IndexAny(keys, "/;"); j >= 0 {
+		keys = keys[:j]
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, strings.Split(keys, ","))
+	s.mu.Unlock()
+
+	var body bytes.Buffer
+	for _, k := range strings.Split(keys, ",") {
+		if strings.HasSuffix(k, ".404") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `<error xmlns="http://www.yahooapis.com/v1/base.rng"><description>%s key %s does not exist.</description></error>`, resource, k)
+			return
+		}
+		if strings.HasSuffix(k, ".410") {
+			continue
+		}
+		if parts := strings.SplitN(k, ".", 2); isCode(parts[0]) {
+			k = "257." + parts[1]
+		}
+		fmt.Fprintf(&body, "<%s><%s_key>%s</%s_key></%s>", resource, resource, k, resource, resource)
+	}
+	fmt.Fprintf(w, `<fantasy_content xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng"><%s>%s</%s></fantasy_content>`, collection, body.String(), collection)
+}
+
+func playerKeys(t *testing.T, s ...string) []PlayerKey {
+	keys := make([]PlayerKey, len(s))
+	for i, k := range s {
+		var err error
+		if keys[i], err = ParsePlayerKey(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return keys
+}
+
+func TestBatchPlayers(t *testing.T) {
+	srv := newBatchServer()
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+
+	var s []string
+	for i := 60; i > 0; i-- {
+		s = append(s, fmt.Sprintf("257.p.%d", i))
+	}
+	s = append(s, "257.p.5", "nfl.p.5479", "257.p.60")
+	keys := playerKeys(t, s...)
+	results := c.Players(context.Background(), keys)
+
+	if len(results) != len(keys) {
+		t.Fatalf("got %d results, want %d", len(results), len(keys))
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("%s: %v", keys[i], r.Err)
+			continue
+		}
+		if r.Key != keys[i] || !sameKey(keys[i].String(), r.Player.PlayerKey) {
+			t.Errorf("result %d: got %s (%s), want %s", i, r.Key, r.Player.PlayerKey, keys[i])
+		}
+	}
+	if got := results[61].Player.PlayerKey; got != "257.p.5479" {
+		t.Errorf("nfl.p.5479: got %s, want 257.p.5479", got)
+	}
+
+	// 61 unique keys take three requests of at most 25 keys each.
+	if len(srv.requests) != 3 {
+		t.Errorf("got %d requests, want 3", len(srv.requests))
+	}
+	seen := make(map[string]bool)
+	for _, req := range srv.requests {
+		if len(req) > MaxKeysPerRequest {
+			t.Errorf("got a request for %d keys, want at most %d", len(req), MaxKeysPerRequest)
+		}
+		for _, k := range req {
+			if seen[k] {
+				t.Errorf("%s requested twice", k)
+			}
+			seen[k] = true
+		}
+	}
+	if len(seen) != 61 {
+		t.Errorf("got %d keys requested, want 61", len(seen))
+	}
+}
+
+func TestBatchPlayersErrors(t *testing.T) {
+	srv := newBatchServer()
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+
+	keys := playerKeys(t, "257.p.1", "257.p.404", "257.p.2", "257.p.410", "257.p.3")
+	results := c.Players(context.Background(), keys)
+
+	for i, r := range results {
+		switch keys[i].ID() {
+		case 404:
+			if !IsNotFound(r.Err) || r.Player != nil {
+				t.Errorf("%s: got %v, %v, want a not found error", keys[i], r.Player, r.Err)
+			}
+		case 410:
+			if r.Err != ErrNotReturned || r.Player != nil {
+				t.Errorf("%s: got %v, %v, want ErrNotReturned", keys[i], r.Player, r.Err)
+			}
+		default:
+			if r.Err != nil || r.Player == nil || r.Player.PlayerKey != keys[i].String() {
+				t.Errorf("%s: got %v, %v", keys[i], r.Player, r.Err)
+			}
+		}
+	}
+	// The rejected chunk is retried one key at a time.
+	if len(srv.requests) != 1+len(keys) {
+		t.Errorf("got %d requests, want %d", len(srv.requests), 1+len(keys))
+	}
+}
+
+func TestBatchTeams(t *testing.T) {
+	srv := newBatchServer()
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient, BatchWorkers: 1}
+
+	league := mustGameKey(t, "nfl").League(431)
+	keys := []TeamKey{league.Team(3), league.Team(404), league.Team(1), league.Team(3)}
+	results := c.Teams(context.Background(), keys, "roster")
+
+	want := []string{"257.l.431.t.3", "", "257.l.431.t.1", "257.l.431.t.3"}
+	for i, r := range results {
+		if want[i] == "" {
+			if !IsNotFound(r.Err) {
+				t.Errorf("%s: got %v, want a not found error", keys[i], r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Team.TeamKey != want[i] {
+			t.Errorf("%s: got %v, %v, want %s", keys[i], r.Team, r.Err, want[i])
+		}
+	}
+	if results[0].Team != results[3].Team {
+		t.Errorf("duplicate keys got different teams")
+	}
+}
+
+func TestBatchCanceled(t *testing.T) {
+	srv := newBatchServer()
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, r := range c.Players(ctx, playerKeys(t, "257.p.1", "257.p.2")) {
+		if r.Err != context.Canceled {
+			t.Errorf("%s: got %v, want %v", r.Key, r.Err, context.Canceled)
+		}
+	}
+	if len(srv.requests) != 0 {
+		t.Errorf("got %d requests, want 0", len(srv.requests))
+	}
+}
+
+func TestSameKey(t *testing.T) {
+	tests := []struct {
+		requested, returned string
+		want                bool
+	}{
+		{"257.p.5479", "257.p.5479", true},
+		{"nfl.p.5479", "257.p.5479", true},
+		{"nfl.l.431.t.1", "257.l.431.t.1", true},
+		{"257.p.5479", "258.p.5479", false},
+		{"nfl.p.5479", "257.p.5478", false},
+		{"nfl.p.5479", "mlb.p.5479", false},
+		{"257.p.5479", "nfl.p.5479", false},
+		{"nfl.l.431.t.1", "257.l.431.t.10", false},
+	}
+	for _, tt := range tests {
+		if got := sameKey(tt.requested, tt.returned); got != tt.want {
+			t.Errorf("sameKey(%q, %q) = %v", tt.requested, tt.returned, got)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package yahooapi
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores response bodies for a limited time. Keys are made up of the
+// user's GUID and the request URI, so a Cache may be shared between the
+// Clients of different users.
+type Cache interface {
+	// Get returns the body stored under key, if it has not expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores body under key for ttl.
+	Set(key string, body []byte, ttl time.Duration)
+
+	// DeleteFunc removes every entry whose key match returns true for.
+	DeleteFunc(match func(key string) bool)
+}
+
+// cacheKey returns the key the response to uri is stored under for the
+// user guid.
+func cacheKey(guid, uri string) string {
+	return guid + " " + uri
+}
+
+// refreshRate returns the refresh_rate attribute of a fantasy_content
+// response, which is the number of seconds Yahoo! considers it fresh for.
+func refreshRate(body []byte) (time.Duration, bool) {
+	var rate string
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var doc struct {
+			FantasyContent struct {
+				RefreshRate json.Number `json:"refresh_rate"`
+			} `json:"fantasy_content"`
+		}
+		if json.Unmarshal(trimmed, &doc) != nil {
+			return 0, false
+		}
+		rate = doc.FantasyContent.RefreshRate.String()
+	} else {
+		// Only the root element is needed, so don't decode the rest.
+		d := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return 0, false
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				for _, attr := range start.Attr {
+					if attr.Name.Local == "refresh_rate" {
+						rate = attr.Value
+					}
+				}
+				break
+			}
+		}
+	}
+	sec, err := strconv.Atoi(rate)
+	if err != nil || sec <= 0 {
+		return 0, false
+	}
+	return time.Duration(sec) * time.Second, true
+}
+
+// refersTo reports whether the URI in a cache key mentions the resource
+// key k, e.g. 223.l.431 is mentioned by .../league/223.l.431/teams and
+// .../team/223.l.431.t.1 but not by .../league/223.l.4310.
+func refersTo(key, k string) bool {
+	if k == "" {
+		return false
+	}
+	for i := 0; ; {
+		j := strings.Index(key[i:], k)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(k)
+		if (start == 0 || !isKeyChar(key[start-1])) && (end == len(key) || !isKeyChar(key[end])) {
+			return true
+		}
+		i = end
+	}
+}
+
+// isKeyChar reports whether b may be part of a game or resource id.
+func isKeyChar(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'z'
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used
+// entry once it is full. It is safe for concurrent use.
+type MemoryCache struct {
+	size int
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns a MemoryCache holding at most size entries. If
+// size is zero or negative the cache is unbounded, and entries are only
+// removed once they expire and are next read.
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(e)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return entry.body, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &memoryEntry{key, body, time.Now().Add(ttl)}
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.size > 0 && c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// DeleteFunc implements Cache.
+func (c *MemoryCache) DeleteFunc(match func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		if match(key) {
+			c.remove(e)
+		}
+	}
+}
+
+func (c *MemoryCache) remove(e *list.Element) {
+	c.lru.Remove(e)
+	delete(c.entries, e.Value.(*memoryEntry).key)
+}
+
+// DiskCache is a Cache that stores each entry in a file of its own within
+// a directory, so that entries survive restarts. Expired entries are
+// removed when they are next read.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache storing entries in dir, which is
+// created if necessary.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir}, nil
+}
+
+// path returns the name of the file key is stored in.
+func (c *DiskCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// read returns the key, expiry time and body stored in the file name. Each
+// file holds the key and the expiry time in Unix seconds on lines of their
+// own, followed by the body.
+func (c *DiskCache) read(name string) (string, time.Time, []byte, bool) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", time.Time{}, nil, false
+	}
+	r := bufio.NewReader(bytes.NewReader(data))
+	key, err := r.ReadString('\n')
+	if err != nil {
+		return "", time.Time{}, nil, false
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", time.Time{}, nil, false
+	}
+	sec, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+	if err != nil {
+		return "", time.Time{}, nil, false
+	}
+	body := data[len(key)+len(line):]
+	return strings.TrimSuffix(key, "\n"), time.Unix(sec, 0), body, true
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	name := c.path(key)
+	stored, expires, body, ok := c.read(name)
+	if !ok || stored != key {
+		return nil, false
+	}
+	if time.Now().After(expires) {
+		os.Remove(name)
+		return nil, false
+	}
+	return body, true
+}
+
+// Set implements Cache. Failures to write the entry are ignored, leaving
+// it uncached.
+func (c *DiskCache) Set(key string, body []byte, ttl time.Duration) {
+	f, err := ioutil.TempFile(c.dir, ".tmp")
+	if err != nil {
+		return
+	}
+	expires := time.Now().Add(ttl).Unix()
+	_, err = f.WriteString(key + "\n" + strconv.FormatInt(expires, 10) + "\n")
+	if err == nil {
+		_, err = f.Write(body)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+	// Renaming replaces any previous entry in one step, so concurrent
+	// readers never see a partly written file.
+	if os.Rename(f.Name(), c.path(key)) != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// DeleteFunc implements Cache.
+func (c *DiskCache) DeleteFunc(match func(key string) bool) {
+	infos, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		name := filepath.Join(c.dir, info.Name())
+		if key, _, _, ok := c.read(name); ok && match(key) {
+			os.Remove(name)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package yahooapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestRefreshRate(t *testing.T) {
+	tests := []struct {
+		body string
+		want time.Duration
+		ok   bool
+	}{
+		{`<?xml version="1.0"?><fantasy_content refresh_rate="60"><league/></fantasy_content>`, time.Minute, true},
+		{`<fantasy_content xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng" refresh_rate="31"/>`, 31 * time.Second, true},
+		{`<fantasy_content><league refresh_rate="60"/></fantasy_content>`, 0, false},
+		{`<fantasy_content refresh_rate="0"/>`, 0, false},
+		{`<fantasy_content refresh_rate="soon"/>`, 0, false},
+		{`{"fantasy_content":{"refresh_rate":"60","league":[]}}`, time.Minute, true},
+		{`{"fantasy_content":{"refresh_rate":60}}`, time.Minute, true},
+		{`{"fantasy_content":{"league":[]}}`, 0, false},
+		{`{"error":{"description":"Invalid league key"}}`, 0, false},
+		{``, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := refreshRate([]byte(tt.body))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: got %v, %v, want %v, %v", tt.body, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestClientTTL(t *testing.T) {
+	body := []byte(`<fantasy_content refresh_rate="60"/>`)
+	c := &Client{TTLs: map[string]time.Duration{
+		"league":     time.Hour,
+		"scoreboard": 30 * time.Second,
+		"players":    0,
+	}}
+	tests := []struct {
+		path string
+		body []byte
+		want time.Duration
+		ok   bool
+	}{
+		{"league/223.l.431", body, time.Hour, true},
+		{"league/223.l.431/scoreboard;week=2", body, 30 * time.Second, true},
+		{"league/223.l.431/players;status=A/stats", body, 0, false},
+		{"team/223.l.431.t.1/roster", body, time.Minute, true},
+		{"team/223.l.431.t.1/roster", nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := c.ttl(tt.path, tt.body)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: got %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	c := NewMemoryCache(3)
+	for _, k := range []string{"a", "b", "c"} {
+		c.Set(k, []byte(k), time.Hour)
+	}
+	c.Get("a")                          // b is now the least recently used
+	c.Set("c", []byte("c2"), time.Hour) // and replacing c doesn't grow the cache
+	c.Set("d", []byte("d"), time.Hour)
+	c.Set("e", []byte("e"), time.Hour)
+
+	for k, want := range map[string]string{"a": "", "b": "", "c": "c2", "d": "d", "e": "e"} {
+		body, ok := c.Get(k)
+		if want == "" {
+			if ok {
+				t.Errorf("%s: got %q, want it evicted", k, body)
+			}
+			continue
+		}
+		if !ok || string(body) != want {
+			t.Errorf("%s: got %q, %v, want %q", k, body, ok, want)
+		}
+	}
+}
+
+func TestMemoryCacheUnbounded(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		c := NewMemoryCache(size)
+		for i := 0; i < 100; i++ {
+			c.Set(fmt.Sprint(i), []byte("x"), time.Hour)
+		}
+		for i := 0; i < 100; i++ {
+			if _, ok := c.Get(fmt.Sprint(i)); !ok {
+				t.Errorf("size %d: entry %d evicted", size, i)
+				break
+			}
+		}
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	c := NewMemoryCache(10)
+	c.Set("a", []byte("a"), -time.Second)
+	if body, ok := c.Get("a"); ok {
+		t.Errorf("got %q, want expired", body)
+	}
+	if len(c.entries) != 0 || c.lru.Len() != 0 {
+		t.Errorf("expired entry not removed")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yahooapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := "<fantasy_content>\n<league/>\n</fantasy_content>\n"
+	c.Set("guid uri", []byte("old"), time.Hour)
+	c.Set("guid uri", []byte(body), time.Hour)
+	c.Set("guid expired", []byte("x"), -time.Minute)
+
+	// A second DiskCache over the same directory sees the same entries.
+	c2, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c2.Get("guid uri"); !ok || string(got) != body {
+		t.Errorf("got %q, %v, want %q", got, ok, body)
+	}
+	if got, ok := c2.Get("guid expired"); ok {
+		t.Errorf("got %q, want expired", got)
+	}
+	if got, ok := c2.Get("guid missing"); ok {
+		t.Errorf("got %q, want a miss", got)
+	}
+
+	// Set writes a temporary file and renames it over the entry, so neither
+	// the replaced entry nor the temporary file is left behind, and the
+	// expired entry was removed by Get.
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].Name() != filepath.Base(c.path("guid uri")) {
+		var names []string
+		for _, info := range infos {
+			names = append(names, info.Name())
+		}
+		t.Errorf("got files %v, want only the entry of %q", names, "guid uri")
+	}
+
+	c.Set("guid other", []byte("y"), time.Hour)
+	c.DeleteFunc(func(key string) bool { return key == "guid uri" })
+	if _, ok := c.Get("guid uri"); ok {
+		t.Errorf("guid uri not deleted")
+	}
+	if _, ok := c.Get("guid other"); !ok {
+		t.Errorf("guid other deleted")
+	}
+}
+
+func TestRefersTo(t *testing.T) {
+	uri := "guid https://fantasysports.yahooapis.com/fantasy/v2/"
+	tests := []struct {
+		key, k string
+		want   bool
+	}{
+		{uri + "league/223.l.431/teams", "223.l.431", true},
+		{uri + "team/223.l.431.t.1", "223.l.431", true},
+		{uri + "league/223.l.4310", "223.l.431", false},
+		{uri + "league/1223.l.431", "223.l.431", false},
+		{uri + "leagues;league_keys=223.l.1,223.l.431/standings", "223.l.431", true},
+		{uri + "team/223.l.431.t.1/roster", "223.l.431.t.1", true},
+		{uri + "team/223.l.431.t.10/roster", "223.l.431.t.1", false},
+		{uri + "league/pnfl.l.431", "223.l.431", false},
+		{uri + "league/223.l.431", "", false},
+	}
+	for _, tt := range tests {
+		if got := refersTo(tt.key, tt.k); got != tt.want {
+			t.Errorf("refersTo(%q, %q) = %v", tt.key, tt.k, got)
+		}
+	}
+}
+
+func TestClientInvalidate(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		fmt.Fprint(w, `<fantasy_content refresh_rate="60"/>`)
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient, Cache: NewMemoryCache(0)}
+	paths := []string{
+		"league/223.l.431",
+		"team/223.l.431.t.1/roster",
+		"team/223.l.431.t.2/roster",
+		"league/223.l.432",
+	}
+	fetchAll := func() int32 {
+		before := atomic.LoadInt32(&hits)
+		for _, p := range paths {
+			if _, err := c.fetch(context.Background(), p); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return atomic.LoadInt32(&hits) - before
+	}
+
+	if n := fetchAll(); n != 4 {
+		t.Errorf("first fetch: got %d requests, want 4", n)
+	}
+	if n := fetchAll(); n != 0 {
+		t.Errorf("cached fetch: got %d requests, want 0", n)
+	}
+	c.InvalidateTeam(mustGameKey(t, "223").League(431).Team(1))
+	if n := fetchAll(); n != 1 {
+		t.Errorf("after InvalidateTeam: got %d requests, want 1", n)
+	}
+	c.InvalidateLeague(mustGameKey(t, "223").League(431))
+	if n := fetchAll(); n != 3 {
+		t.Errorf("after InvalidateLeague: got %d requests, want 3", n)
+	}
+}
@@ -0,0 +1,218 @@
+package yahooapi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/oauth2"
+)
+
+const defaultBaseURL = "https://fantasysports.yahooapis.com/fantasy/v2/"
+
+// Client makes Fantasy Sports API requests on behalf of a single Yahoo!
+// user. Unlike the YahooConfig handlers it has no dependency on an incoming
+// *http.Request, so it can be used from cron jobs, command line tools and
+// tests.
+type Client struct {
+	// BaseURL is the root that resource paths are appended to. It defaults
+	// to the public Fantasy Sports API endpoint.
+	BaseURL string
+
+	// Format is the representation requested from the API. The zero value
+	// requests XML.
+	Format Format
+
+	// Cache, if set, stores responses for the time given by TTLs or, for
+	// resources not listed there, by the response's refresh_rate. Responses
+	// with neither are not cached.
+	Cache Cache
+
+	// GUID identifies the user in Cache keys. It must be set when a Cache
+	// is shared between the Clients of different users.
+	GUID string
+
+	// TTLs maps resource and sub-resource names, such as "game" or
+	// "scoreboard", to how long responses for them are cached. The last
+	// name in a request's path that is listed applies.
+	TTLs map[string]time.Duration
+
+	// BatchWorkers is the number of requests Players and Teams have in
+	// flight at once. If zero, DefaultBatchWorkers is used.
+	BatchWorkers int
+
+	client *http.Client
+	retry  *RetryTransport
+}
+
+// NewClient returns a Client that sends requests with httpClient, which is
+// expected to add the user's OAuth credentials to each request. If
+// httpClient is nil, http.DefaultClient is used.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		BaseURL: defaultBaseURL,
+		client:  httpClient,
+	}
+}
+
+// NewTokenSourceClient returns a Client that authorizes its requests with
+// tokens from src. Throttled and failed GETs are retried by a
+// RetryTransport beneath the OAuth2 transport; a *http.Client stored in ctx
+// under oauth2.HTTPClient is used beneath that. The client is not valid
+// beyond the lifetime of ctx.
+func NewTokenSourceClient(ctx context.Context, src oauth2.TokenSource) *Client {
+	ctx, rt := withRetryTransport(ctx)
+	c := NewClient(oauth2.NewClient(ctx, src))
+	c.retry = rt
+	return c
+}
+
+// Retries returns the number of requests c has retried, or 0 if it was not
+// created with a RetryTransport.
+func (c *Client) Retries() int64 {
+	if c.retry == nil {
+		return 0
+	}
+	return c.retry.Retries()
+}
+
+// fetch requests path, relative to BaseURL, and returns the response body.
+// Responses other than 200 OK are returned as an *APIError.
+func (c *Client) fetch(ctx context.Context, path string) ([]byte, error) {
+	url := c.BaseURL + path
+	if c.Format == JSON {
+		url += "?format=json"
+	}
+	key := cacheKey(c.GUID, url)
+	if c.Cache != nil {
+		if body, ok := c.Cache.Get(key); ok {
+			return body, nil
+		}
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, body, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(url, res, body)
+	}
+	if c.Cache != nil {
+		if ttl, ok := c.ttl(path, body); ok {
+			c.Cache.Set(key, body, ttl)
+		}
+	}
+	return body, nil
+}
+
+// send submits the XML document doc to path, relative to BaseURL, with the
+// given method and returns the response body. Responses other than 200 OK
+// and 201 Created are returned as an *APIError. Nothing is cached.
+func (c *Client) send(ctx context.Context, method, path string, doc []byte) ([]byte, error) {
+	url := c.BaseURL + path
+	if c.Format == JSON {
+		url += "?format=json"
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(doc))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	res, body, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return nil, newAPIError(url, res, body)
+	}
+	return body, nil
+}
+
+// do sends req and reads the whole response body.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	res, err := ctxhttp.Do(ctx, c.client, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, body, nil
+}
+
+// ttl returns how long the response body to path may be cached for.
+func (c *Client) ttl(path string, body []byte) (time.Duration, bool) {
+	parts := strings.Split(path, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		name := strings.SplitN(parts[i], ";", 2)[0]
+		if ttl, ok := c.TTLs[name]; ok {
+			return ttl, ttl > 0
+		}
+	}
+	return refreshRate(body)
+}
+
+// InvalidateLeague removes the cached responses of every user that refer
+// to the league identified by leagueKey or to any of its teams. Responses
+// requested with a game_code rather than a game_id in their keys, or which
+// name the league only in their body, are not removed.
+func (c *Client) InvalidateLeague(leagueKey LeagueKey) {
+	c.invalidate(leagueKey.String())
+}
+
+// InvalidateTeam removes the cached responses of every user that refer to
+// the team identified by teamKey, with the same limits as
+// InvalidateLeague.
+func (c *Client) InvalidateTeam(teamKey TeamKey) {
+	c.invalidate(teamKey.String())
+}
+
+func (c *Client) invalidate(k string) {
+	if c.Cache == nil || k == "" {
+		return
+	}
+	c.Cache.DeleteFunc(func(key string) bool {
+		return refersTo(key, k)
+	})
+}
+
+// get requests path, relative to BaseURL, and decodes the response into v.
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	body, err := c.fetch(ctx, path)
+	if err != nil {
+		return err
+	}
+	return unmarshal(body, v)
+}
+
+// Get executes q and decodes the response into v, which is typically a
+// *FantasyContent or one of the package's collection types.
+func (c *Client) Get(ctx context.Context, q *Query, v interface{}) error {
+	path, err := q.Path()
+	if err != nil {
+		return err
+	}
+	return c.get(ctx, path, v)
+}
+
+// Execute executes q and returns the decoded response.
+func (c *Client) Execute(ctx context.Context, q *Query) (*FantasyContent, error) {
+	var content FantasyContent
+	if err := c.Get(ctx, q, &content); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
@@ -0,0 +1,110 @@
+package yahooapi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// StatusRateLimited is the non-standard status code Yahoo! responds with
+// when a user or application has made too many requests.
+const StatusRateLimited = 999
+
+// APIError is returned by Client methods when the Fantasy Sports API
+// responds with anything other than 200 OK. Yahoo! describes most failures
+// with a body like the following, or its format=json equivalent:
+//
+//   <error xml:lang="en-us" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431x">
+//     <description>Invalid league key</description>
+//     <detail/>
+//   </error>
+//
+// which is decoded into URI and Description. Bodies that are not in that
+// format leave them empty.
+type APIError struct {
+	XMLName     xml.Name `xml:"error" json:"-"`
+	StatusCode  int      `xml:"-" json:"status_code"`
+	URI         string   `xml:"uri,attr" json:"uri,omitempty"`
+	Description string   `xml:"description" json:"description,omitempty"`
+	Detail      string   `xml:"detail" json:"detail,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Description
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.URI == "" {
+		return fmt.Sprintf("yahooapi: %d %s", e.StatusCode, msg)
+	}
+	return fmt.Sprintf("yahooapi: %d %s (%s)", e.StatusCode, msg, e.URI)
+}
+
+// RefetchError is returned by SetLineup when the change was saved but the
+// updated resource could not be fetched afterwards. Err is the error of
+// the fetch; retrying the change is not necessary.
+type RefetchError struct {
+	Err error
+}
+
+func (e *RefetchError) Error() string {
+	return "yahooapi: change saved but not refetched: " + e.Err.Error()
+}
+
+// newAPIError builds an APIError from a non-200 response to url and its
+// body.
+func newAPIError(url string, res *http.Response, body []byte) *APIError {
+	e := &APIError{}
+	// A body that doesn't parse still leaves a useful error behind, so the
+	// decoding error itself is not interesting.
+	unmarshal(body, e)
+	e.StatusCode = res.StatusCode
+	if e.URI == "" {
+		e.URI = url
+	}
+	return e
+}
+
+// IsUnauthorized reports whether err was caused by a missing, invalid or
+// expired OAuth token.
+func IsUnauthorized(err error) bool {
+	e, ok := err.(*APIError)
+	return ok && e.StatusCode == http.StatusUnauthorized && !isPrivateLeague(e)
+}
+
+// IsNotFound reports whether err was caused by a request for a resource
+// that does not exist, such as an unknown league or player key.
+func IsNotFound(err error) bool {
+	e, ok := err.(*APIError)
+	if !ok {
+		return false
+	}
+	if e.StatusCode == http.StatusNotFound {
+		return true
+	}
+	desc := strings.ToLower(e.Description)
+	return e.StatusCode == http.StatusBadRequest &&
+		(strings.HasPrefix(desc, "invalid") || strings.Contains(desc, "does not exist"))
+}
+
+// IsRateLimited reports whether err was caused by Yahoo! throttling the
+// user or application.
+func IsRateLimited(err error) bool {
+	e, ok := err.(*APIError)
+	return ok && (e.StatusCode == StatusRateLimited || e.StatusCode == http.StatusTooManyRequests)
+}
+
+// IsForbiddenPrivateLeague reports whether err was caused by requesting
+// data from a private league the user is not a member of. Yahoo! reports
+// this with a 401 or 403 status, so it is recognized by its description;
+// other 403 responses are not private league errors.
+func IsForbiddenPrivateLeague(err error) bool {
+	e, ok := err.(*APIError)
+	return ok && isPrivateLeague(e)
+}
+
+func isPrivateLeague(e *APIError) bool {
+	desc := strings.ToLower(e.Description)
+	return strings.Contains(desc, "not in this league") || strings.Contains(desc, "private league")
+}
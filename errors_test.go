@@ -0,0 +1,79 @@
+package yahooapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestErrorPredicates(t *testing.T) {
+	tests := []struct {
+		err                                                error
+		unauthorized, notFound, rateLimited, privateLeague bool
+	}{
+		{&APIError{StatusCode: 401, Description: "Please provide valid credentials."}, true, false, false, false},
+		{&APIError{StatusCode: 401, Description: "You are not allowed to view this page because you are not in this league."}, false, false, false, true},
+		{&APIError{StatusCode: 403, Description: "This is a private league."}, false, false, false, true},
+		{&APIError{StatusCode: 403, Description: "Forbidden"}, false, false, false, false},
+		{&APIError{StatusCode: 404}, false, true, false, false},
+		{&APIError{StatusCode: 400, Description: "Invalid league key"}, false, true, false, false},
+		{&APIError{StatusCode: 400, Description: "Player key 223.p.99999 does not exist."}, false, true, false, false},
+		{&APIError{StatusCode: 400, Description: "Week must be a number"}, false, false, false, false},
+		{&APIError{StatusCode: 999}, false, false, true, false},
+		{&APIError{StatusCode: 429}, false, false, true, false},
+		{&APIError{StatusCode: 500}, false, false, false, false},
+		{errors.New("connection reset"), false, false, false, false},
+		{nil, false, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := IsUnauthorized(tt.err); got != tt.unauthorized {
+			t.Errorf("IsUnauthorized(%v) = %v", tt.err, got)
+		}
+		if got := IsNotFound(tt.err); got != tt.notFound {
+			t.Errorf("IsNotFound(%v) = %v", tt.err, got)
+		}
+		if got := IsRateLimited(tt.err); got != tt.rateLimited {
+			t.Errorf("IsRateLimited(%v) = %v", tt.err, got)
+		}
+		if got := IsForbiddenPrivateLeague(tt.err); got != tt.privateLeague {
+			t.Errorf("IsForbiddenPrivateLeague(%v) = %v", tt.err, got)
+		}
+	}
+}
+
+// TestAPIErrorBareResponse uses a transport that, unlike http.Transport,
+// returns responses without their Request.
+func TestAPIErrorBareResponse(t *testing.T) {
+	tests := []struct {
+		body string
+		uri  string
+	}{
+		{"", "http://example.com/fantasy/v2/league/223.l.431"},
+		{`<error xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431"><description>Invalid league key</description></error>`,
+			"http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431"},
+	}
+	for _, tt := range tests {
+		body := tt.body
+		c := &Client{BaseURL: "http://example.com/fantasy/v2/", client: &http.Client{
+			Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: 400, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+			}),
+		}}
+		_, fetchErr := c.fetch(context.Background(), "league/223.l.431")
+		_, sendErr := c.send(context.Background(), "PUT", "league/223.l.431", nil)
+		for _, err := range []error{fetchErr, sendErr} {
+			e, ok := err.(*APIError)
+			if !ok {
+				t.Errorf("got %v, want an *APIError", err)
+				continue
+			}
+			if e.StatusCode != 400 || e.URI != tt.uri {
+				t.Errorf("got status %d URI %s, want 400 %s", e.StatusCode, e.URI, tt.uri)
+			}
+		}
+	}
+}
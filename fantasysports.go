@@ -1,16 +1,39 @@
 //Fantasy Sports API
 //
 // Documentation from https://developer.yahoo.com/fantasysports/guide
+//
+// JSON representation
+//
+// The resource types marshal with encoding/json into the shape served by the
+// YahooConfig handlers, which follows the XML closely:
+//
+//   - keys are the snake_case element names, e.g. league_key, num_teams
+//   - collections such as <teams><team/></teams> become arrays named after
+//     the collection, e.g. "teams": [{...}]
+//   - numbers and booleans are JSON numbers and true/false rather than
+//     strings and "1"/"0"
+//   - Timestamp fields are RFC 3339 strings and Date fields YYYY-MM-DD
+//   - empty strings, collections and sub-resources are omitted, as are zero
+//     numbers and false, except where zero is a value in its own right:
+//     stat ids and values, sort orders, wins, losses and ties, percent
+//     owned, average picks and rounds, percent drafted and is_starting
+//     are always present
+//   - zero Timestamps and Dates are null
+//
+// The files in testdata/golden record the representation of each sample
+// response.
 package yahooapi
 
 import (
-	"io/ioutil"
-	"log"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 	// "encoding/json"
 	"encoding/xml"
-	"fmt"
 	"github.com/gorilla/mux"
+	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
 
@@ -182,6 +205,24 @@ import (
 // parameters that can be applied to various Resources or Collections.
 //
 
+// FantasyContent is the envelope around every Fantasy Sports API response.
+// Only the field matching the requested resource or collection is filled in.
+type FantasyContent struct {
+	XMLName      xml.Name              `xml:"fantasy_content" json:"-"`
+	URI          string                `xml:"uri,attr" json:"uri,omitempty"`
+	Game         *GameResource         `xml:"game" json:"game,omitempty"`
+	Games        []GameResource        `xml:"games>game" json:"games,omitempty"`
+	League       *LeagueResource       `xml:"league" json:"league,omitempty"`
+	Leagues      []LeagueResource      `xml:"leagues>league" json:"leagues,omitempty"`
+	Team         *TeamResource         `xml:"team" json:"team,omitempty"`
+	Teams        []TeamResource        `xml:"teams>team" json:"teams,omitempty"`
+	Player       *PlayerResource       `xml:"player" json:"player,omitempty"`
+	Players      []PlayerResource      `xml:"players>player" json:"players,omitempty"`
+	Transaction  *TransactionResource  `xml:"transaction" json:"transaction,omitempty"`
+	Transactions []TransactionResource `xml:"transactions>transaction" json:"transactions,omitempty"`
+	Users        []UserResource        `xml:"users>user" json:"users,omitempty"`
+}
+
 // Game resource
 //
 // Description
@@ -238,15 +279,24 @@ import (
 //     </fantasy_content>
 //
 type GameResource struct {
-	XMLName  xml.Name         `xml:"game",json:"-"`
-	Game_key string           `xml:"game_key",json:",omitempty"`
-	Game_id  string           `xml:"game_id",json:",omitempty"`
-	Namecode string           `xml:"namecode",json:",omitempty"`
-	Type     string           `xml:"type",json:",omitempty"`
-	Url      string           `xml:"url",json:",omitempty"`
-	Season   string           `xml:"season",json:",omitempty"`
-	Leagues  []LeagueResource `xml:"leagues>league",json:",omitempty"`
-	Teams    []TeamResource   `xml:"teams>team",json:",omitempty"`
+	XMLName            xml.Name                 `xml:"game" json:"-"`
+	GameKey            string                   `xml:"game_key" json:"game_key,omitempty"`
+	GameID             int                      `xml:"game_id" json:"game_id,omitempty"`
+	Name               string                   `xml:"name" json:"name,omitempty"`
+	Code               string                   `xml:"code" json:"code,omitempty"`
+	Type               string                   `xml:"type" json:"type,omitempty"`
+	URL                string                   `xml:"url" json:"url,omitempty"`
+	Season             int                      `xml:"season" json:"season,omitempty"`
+	IsRegistrationOver bool                     `xml:"is_registration_over" json:"is_registration_over,omitempty"`
+	IsGameOver         bool                     `xml:"is_game_over" json:"is_game_over,omitempty"`
+	IsOffseason        bool                     `xml:"is_offseason" json:"is_offseason,omitempty"`
+	GameWeeks          []GameWeekResource       `xml:"game_weeks>game_week" json:"game_weeks,omitempty"`
+	StatCategories     []StatCategoryResource   `xml:"stat_categories>stats>stat" json:"stat_categories,omitempty"`
+	PositionTypes      []PositionTypeResource   `xml:"position_types>position_type" json:"position_types,omitempty"`
+	RosterPositions    []RosterPositionResource `xml:"roster_positions>roster_position" json:"roster_positions,omitempty"`
+	Leagues            []LeagueResource         `xml:"leagues>league" json:"leagues,omitempty"`
+	Teams              []TeamResource           `xml:"teams>team" json:"teams,omitempty"`
+	Players            []PlayerResource         `xml:"players>player" json:"players,omitempty"`
 }
 
 /*
@@ -292,6 +342,115 @@ http://fantasysports.yahooapis.com/fantasy/v2/game/nfl
 
 */
 
+// GameWeekResource is a scoring week of a game, from the game_weeks
+// sub-resource.
+//
+//   <game_weeks count="17">
+//     <game_week>
+//       <week>1</week>
+//       <start>2011-09-08</start>
+//       <end>2011-09-12</end>
+//     </game_week>
+//     ...
+//   </game_weeks>
+type GameWeekResource struct {
+	XMLName xml.Name `xml:"game_week" json:"-"`
+	Week    int      `xml:"week" json:"week,omitempty"`
+	Start   Date     `xml:"start" json:"start"`
+	End     Date     `xml:"end" json:"end"`
+}
+
+// StatCategoryResource describes a stat, from the stat_categories
+// sub-resource of a game or the stat_categories of league settings. The
+// position types are those the stat applies to.
+//
+//   <stat_categories>
+//     <stats>
+//       <stat>
+//         <stat_id>4</stat_id>
+//         <name>Passing Yards</name>
+//         <display_name>Pass Yds</display_name>
+//         <sort_order>1</sort_order>
+//         <position_types>
+//           <position_type>O</position_type>
+//         </position_types>
+//       </stat>
+//       ...
+//     </stats>
+//   </stat_categories>
+type StatCategoryResource struct {
+	XMLName       xml.Name `xml:"stat" json:"-"`
+	StatID        int      `xml:"stat_id" json:"stat_id"`
+	Name          string   `xml:"name" json:"name,omitempty"`
+	DisplayName   string   `xml:"display_name" json:"display_name,omitempty"`
+	SortOrder     int      `xml:"sort_order" json:"sort_order"`
+	PositionTypes []string `xml:"position_types>position_type" json:"position_types,omitempty"`
+
+	// The stat_categories of league settings carry a single position type
+	// and whether the league uses the stat.
+	PositionType      string `xml:"position_type" json:"position_type,omitempty"`
+	Enabled           bool   `xml:"enabled" json:"enabled,omitempty"`
+	IsOnlyDisplayStat bool   `xml:"is_only_display_stat" json:"is_only_display_stat,omitempty"`
+}
+
+// PositionTypeResource is a kind of position, such as offense (O) or
+// defense (DT), from the position_types sub-resource.
+//
+//   <position_types>
+//     <position_type>
+//       <type>O</type>
+//       <display_name>Offense</display_name>
+//     </position_type>
+//     ...
+//   </position_types>
+type PositionTypeResource struct {
+	XMLName     xml.Name `xml:"position_type" json:"-"`
+	Type        string   `xml:"type" json:"type,omitempty"`
+	DisplayName string   `xml:"display_name" json:"display_name,omitempty"`
+}
+
+// RosterPositionResource is a slot on a roster, from the roster_positions
+// sub-resource of a game or the roster_positions of league settings.
+//
+//   <roster_positions>
+//     <roster_position>
+//       <position>QB</position>
+//       <abbreviation>QB</abbreviation>
+//       <display_name>Quarterback</display_name>
+//       <position_type>O</position_type>
+//     </roster_position>
+//     ...
+//   </roster_positions>
+type RosterPositionResource struct {
+	XMLName      xml.Name `xml:"roster_position" json:"-"`
+	Position     string   `xml:"position" json:"position,omitempty"`
+	Abbreviation string   `xml:"abbreviation" json:"abbreviation,omitempty"`
+	DisplayName  string   `xml:"display_name" json:"display_name,omitempty"`
+	PositionType string   `xml:"position_type" json:"position_type,omitempty"`
+	IsBench      bool     `xml:"is_bench" json:"is_bench,omitempty"`
+
+	// Count is the number of slots for the position in league settings.
+	Count int `xml:"count" json:"count,omitempty"`
+}
+
+// Game fetches the game identified by gameKey along with the sub-resources
+// named in out, e.g. "game_weeks", "stat_categories", "position_types" and
+// "roster_positions".
+func (c *Client) Game(ctx context.Context, gameKey GameKey, out ...string) (*GameResource, error) {
+	q := Game(gameKey.String())
+	if len(out) > 0 {
+		q = q.Out(out...)
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.Game == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Game, nil
+}
+
 /*
 Games collection¶
 
@@ -350,6 +509,101 @@ OR
 all teams for user: http://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games/teams
 */
 
+// GameType is the kind of a fantasy game, used to filter games collections.
+type GameType string
+
+const (
+	// FullGame is a season long game in which managers draft and trade
+	// players, such as nfl or mlb.
+	FullGame GameType = "full"
+
+	// PickemTeamGame is a pick'em game in which users pick the winners of
+	// individual matchups.
+	PickemTeamGame GameType = "pickem-team"
+
+	// PickemGroupGame is a pick'em game in which users pick winners within
+	// groups of teams, such as a tournament bracket.
+	PickemGroupGame GameType = "pickem-group"
+
+	// PickemTeamListGame is a pick'em game in which users pick from a list
+	// of teams, such as a survivor pool.
+	PickemTeamListGame GameType = "pickem-team-list"
+)
+
+// GamesFilter restricts a games collection to the games matching all of
+// its non-zero fields. For instance, the current NFL and MLB games are
+//
+//   &GamesFilter{IsAvailable: true, GameCodes: []string{"nfl", "mlb"}}
+type GamesFilter struct {
+	// GameKeys lists the games to include.
+	GameKeys []GameKey
+
+	// IsAvailable only includes games currently in season.
+	IsAvailable bool
+
+	// GameTypes, GameCodes and Seasons only include games of the listed
+	// types, codes such as nfl, and seasons such as 2011.
+	GameTypes []GameType
+	GameCodes []string
+	Seasons   []int
+}
+
+// apply adds the parameters for f to the games collection at the end of q.
+// A nil filter leaves q unchanged.
+func (f *GamesFilter) apply(q *Query) (*Query, error) {
+	if f == nil {
+		return q, nil
+	}
+	if len(f.GameKeys) > 0 {
+		q = q.GameKeys(gameKeyStrings(f.GameKeys)...)
+	}
+	if f.IsAvailable {
+		q = q.Param("is_available", "1")
+	}
+	if len(f.GameTypes) > 0 {
+		types := make([]string, len(f.GameTypes))
+		for i, t := range f.GameTypes {
+			switch t {
+			case FullGame, PickemTeamGame, PickemGroupGame, PickemTeamListGame:
+			default:
+				return nil, fmt.Errorf("yahooapi: invalid game type %q", t)
+			}
+			types[i] = string(t)
+		}
+		q = q.Param("game_types", types...)
+	}
+	if len(f.GameCodes) > 0 {
+		for _, code := range f.GameCodes {
+			if !isCode(code) {
+				return nil, fmt.Errorf("yahooapi: invalid game code %q", code)
+			}
+		}
+		q = q.Param("game_codes", f.GameCodes...)
+	}
+	if len(f.Seasons) > 0 {
+		seasons := make([]string, len(f.Seasons))
+		for i, season := range f.Seasons {
+			seasons[i] = strconv.Itoa(season)
+		}
+		q = q.Param("seasons", seasons...)
+	}
+	return q, nil
+}
+
+// Games fetches the games collection restricted by filter, which may be
+// nil.
+func (c *Client) Games(ctx context.Context, filter *GamesFilter) ([]GameResource, error) {
+	q, err := filter.apply(Games())
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return content.Games, nil
+}
+
 // League resource
 //
 // Description
@@ -362,39 +616,49 @@ all teams for user: http://fantasysports.yahooapis.com/fantasy/v2/users;use_logi
 // the base of your URI by using the global ````. A particular user can only
 // retrieve data for private leagues of which they are a member, or for public
 // leagues.
+//
+// EditKey is the first coverage period whose lineups can still be edited:
+// a week such as "17" in weekly games, or a date such as "2011-07-23" in
+// daily games like baseball, basketball and hockey.
 type LeagueResource struct {
-	XMLName               xml.Name `xml:"league",json:"-"`
-	LeagueKey             string   `xml:"league_key",json:",omitempty"`
-	LeagueID              string   `xml:"league_id",json:",omitempty"`
-	Name                  string   `xml:"name",json:",omitempty"`
-	URL                   string   `xml:"url",json:",omitempty"`
-	Password              string   `xml:"password",json:",omitempty"`
-	LeageChatID           string   `xml:"league_chat_id",json:",omitempty"`
-	DraftStatus           string   `xml:"draft_status",json:",omitempty"`
-	NumberOfTeams         string   `xml:"num_teams",json:",omitempty"`
-	EditKey               string   `xml:"edit_key",json:",omitempty"`
-	WeeklyDeadline        string   `xml:"weekly_deadline",json:",omitempty"`
-	LeagueUpdateTimestamp string   `xml:"league_update_timestamp",json:",omitempty"`
-	ScoringType           string   `xml:"scoring_type",json:",omitempty"`
-	LeagueType            string   `xml:"league_type",json:",omitempty"`
-	Renew                 string   `xml:"renew",json:",omitempty"`
-	Renewed               string   `xml:"renewed",json:",omitempty"`
-	ShortInvitationURL    string   `xml:"short_invitation_url",json:",omitempty"`
-	IsProLeague           string   `xml:"is_pro_league",json:",omitempty"`
-	CurrentWeek           string   `xml:"current_week",json:",omitempty"`
-	StartWeek             string   `xml:"start_week",json:",omitempty"`
-	StartDate             string   `xml:"start_date",json:",omitempty"`
-	EndWeek               string   `xml:"end_week",json:",omitempty"`
-	EndDate               string   `xml:"end_date",json:",omitempty"`
-	GameCode              string   `xml:"game_code",json:",omitempty"`
-	Season                string   `xml:"season",json:",omitempty"`
-	ScoreBoard	ScoreBoardResource `xml:"scoreboard",json:",omitempty"`
+	XMLName               xml.Name              `xml:"league" json:"-"`
+	LeagueKey             string                `xml:"league_key" json:"league_key,omitempty"`
+	LeagueID              int                   `xml:"league_id" json:"league_id,omitempty"`
+	Name                  string                `xml:"name" json:"name,omitempty"`
+	URL                   string                `xml:"url" json:"url,omitempty"`
+	Password              string                `xml:"password" json:"password,omitempty"`
+	LeagueChatID          string                `xml:"league_chat_id" json:"league_chat_id,omitempty"`
+	DraftStatus           string                `xml:"draft_status" json:"draft_status,omitempty"`
+	NumberOfTeams         int                   `xml:"num_teams" json:"num_teams,omitempty"`
+	EditKey               string                `xml:"edit_key" json:"edit_key,omitempty"`
+	WeeklyDeadline        string                `xml:"weekly_deadline" json:"weekly_deadline,omitempty"`
+	LeagueUpdateTimestamp Timestamp             `xml:"league_update_timestamp" json:"league_update_timestamp,omitempty"`
+	ScoringType           string                `xml:"scoring_type" json:"scoring_type,omitempty"`
+	LeagueType            string                `xml:"league_type" json:"league_type,omitempty"`
+	Renew                 string                `xml:"renew" json:"renew,omitempty"`
+	Renewed               string                `xml:"renewed" json:"renewed,omitempty"`
+	ShortInvitationURL    string                `xml:"short_invitation_url" json:"short_invitation_url,omitempty"`
+	IsProLeague           bool                  `xml:"is_pro_league" json:"is_pro_league,omitempty"`
+	CurrentWeek           int                   `xml:"current_week" json:"current_week,omitempty"`
+	StartWeek             int                   `xml:"start_week" json:"start_week,omitempty"`
+	StartDate             Date                  `xml:"start_date" json:"start_date,omitempty"`
+	EndWeek               int                   `xml:"end_week" json:"end_week,omitempty"`
+	EndDate               Date                  `xml:"end_date" json:"end_date,omitempty"`
+	GameCode              string                `xml:"game_code" json:"game_code,omitempty"`
+	Season                int                   `xml:"season" json:"season,omitempty"`
+	ScoreBoard            *ScoreBoardResource   `xml:"scoreboard" json:"scoreboard,omitempty"`
+	Players               []PlayerResource      `xml:"players>player" json:"players,omitempty"`
+	IsFinished            bool                  `xml:"is_finished" json:"is_finished,omitempty"`
+	Settings              *LeagueSettings       `xml:"settings" json:"settings,omitempty"`
+	Standings             []TeamResource        `xml:"standings>teams>team" json:"standings,omitempty"`
+	DraftResults          []DraftResultResource `xml:"draft_results>draft_result" json:"draft_results,omitempty"`
+	Transactions          []TransactionResource `xml:"transactions>transaction" json:"transactions,omitempty"`
 }
 
 type LeagueCollection struct {
-	XMLName xml.Name         `xml:"fantasy_content",json:"-"`
-	Leagues []LeagueResource `xml:"leagues>league",json:",omitempty"`
-	Body string
+	XMLName xml.Name         `xml:"fantasy_content" json:"-"`
+	Leagues []LeagueResource `xml:"leagues>league" json:"leagues,omitempty"`
+	Body    string           `xml:"-" json:"-"`
 }
 
 // HTTP Operations Supported
@@ -995,44 +1259,119 @@ type LeagueCollection struct {
 //   </league>
 // </fantasy_content>
 
-func (y *YahooConfig) GetLeagueStandings(r *http.Request) *LeagueCollection {
-	session, err := y.SessionStore.Get(r, "session-name")
+// LeagueSettings is the settings sub-resource of a league.
+type LeagueSettings struct {
+	XMLName xml.Name `xml:"settings" json:"-"`
+
+	// Draft
+	DraftType        string    `xml:"draft_type" json:"draft_type,omitempty"`
+	IsAuctionDraft   bool      `xml:"is_auction_draft" json:"is_auction_draft,omitempty"`
+	DraftTime        Timestamp `xml:"draft_time" json:"draft_time"`
+	DraftPickTime    int       `xml:"draft_pick_time" json:"draft_pick_time,omitempty"`
+	PostDraftPlayers string    `xml:"post_draft_players" json:"post_draft_players,omitempty"`
+
+	ScoringType string `xml:"scoring_type" json:"scoring_type,omitempty"`
+	MaxTeams    int    `xml:"max_teams" json:"max_teams,omitempty"`
+
+	// Playoffs
+	UsesPlayoff                bool `xml:"uses_playoff" json:"uses_playoff,omitempty"`
+	PlayoffStartWeek           int  `xml:"playoff_start_week" json:"playoff_start_week,omitempty"`
+	NumPlayoffTeams            int  `xml:"num_playoff_teams" json:"num_playoff_teams,omitempty"`
+	HasPlayoffConsolationGames bool `xml:"has_playoff_consolation_games" json:"has_playoff_consolation_games,omitempty"`
+	NumPlayoffConsolationTeams int  `xml:"num_playoff_consolation_teams" json:"num_playoff_consolation_teams,omitempty"`
+	UsesPlayoffReseeding       bool `xml:"uses_playoff_reseeding" json:"uses_playoff_reseeding,omitempty"`
+	UsesLockEliminatedTeams    bool `xml:"uses_lock_eliminated_teams" json:"uses_lock_eliminated_teams,omitempty"`
+
+	// Waivers and adds. WaiverTime is in days.
+	WaiverType    string `xml:"waiver_type" json:"waiver_type,omitempty"`
+	WaiverRule    string `xml:"waiver_rule" json:"waiver_rule,omitempty"`
+	WaiverTime    int    `xml:"waiver_time" json:"waiver_time,omitempty"`
+	UsesFAAB      bool   `xml:"uses_faab" json:"uses_faab,omitempty"`
+	MaxAdds       int    `xml:"max_adds" json:"max_adds,omitempty"`
+	MaxWeeklyAdds int    `xml:"max_weekly_adds" json:"max_weekly_adds,omitempty"`
+
+	// Trades. TradeRejectTime is in days.
+	TradeEndDate       Date   `xml:"trade_end_date" json:"trade_end_date"`
+	TradeRatifyType    string `xml:"trade_ratify_type" json:"trade_ratify_type,omitempty"`
+	TradeRejectTime    int    `xml:"trade_reject_time" json:"trade_reject_time,omitempty"`
+	CanTradeDraftPicks bool   `xml:"can_trade_draft_picks" json:"can_trade_draft_picks,omitempty"`
+
+	RosterPositions []RosterPositionResource `xml:"roster_positions>roster_position" json:"roster_positions,omitempty"`
+	StatCategories  []StatCategoryResource   `xml:"stat_categories>stats>stat" json:"stat_categories,omitempty"`
+	StatModifiers   []StatModifierResource   `xml:"stat_modifiers>stats>stat" json:"stat_modifiers,omitempty"`
+	Divisions       []DivisionResource       `xml:"divisions>division" json:"divisions,omitempty"`
+}
+
+// StatModifierResource is the number of points a league awards per unit of
+// a stat.
+type StatModifierResource struct {
+	XMLName xml.Name `xml:"stat" json:"-"`
+	StatID  int      `xml:"stat_id" json:"stat_id"`
+	Value   float64  `xml:"value" json:"value"`
+}
+
+// DivisionResource is a division of a league.
+type DivisionResource struct {
+	XMLName    xml.Name `xml:"division" json:"-"`
+	DivisionID int      `xml:"division_id" json:"division_id,omitempty"`
+	Name       string   `xml:"name" json:"name,omitempty"`
+}
+
+// LeagueSettings fetches the settings of the league identified by
+// leagueKey.
+func (c *Client) LeagueSettings(ctx context.Context, leagueKey LeagueKey) (*LeagueSettings, error) {
+	content, err := c.Execute(ctx, League(leagueKey.String()).Settings())
 	if err != nil {
-		log.Println(err.Error(), 500)
-		return nil
+		return nil, err
 	}
-
-	tok, ok := session.Values["token"].(*oauth2.Token)
-	if !ok {
-		log.Println("error deserializing token from session")
-		return nil
+	if content.League == nil || content.League.Settings == nil {
+		return nil, ErrNotReturned
 	}
-	client := y.conf.Client(oauth2.NoContext, tok)
+	return content.League.Settings, nil
+}
 
-	vars := mux.Vars(r)
-	league_keys := vars["league_keys"]
-	url := fmt.Sprintf("http://fantasysports.yahooapis.com/fantasy/v2/league/%s/standings", league_keys)
-	req, err := http.NewRequest("GET", url, nil)
+// LeagueStandings fetches the teams of the league identified by leagueKey
+// in order of their rank, with their TeamStandings filled in.
+func (c *Client) LeagueStandings(ctx context.Context, leagueKey LeagueKey) ([]TeamResource, error) {
+	content, err := c.Execute(ctx, League(leagueKey.String()).Standings())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	if content.League == nil {
+		return nil, ErrNotReturned
 	}
+	return content.League.Standings, nil
+}
 
-	res, err := client.Do(req)
+func (y *YahooConfig) GetLeagueStandings(r *http.Request) ([]TeamResource, error) {
+	leagueKey, err := ParseLeagueKey(mux.Vars(r)["league_keys"])
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	body, err := ioutil.ReadAll(res.Body)
+	client, err := y.Client(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer res.Body.Close()
-	var leagueCollection LeagueCollection
-	if xml.Unmarshal(body, &leagueCollection); err != nil {
-		log.Fatal(err)
-	}
-	leagueCollection.Body = string(body)
+	return client.LeagueStandings(oauth2.NoContext, leagueKey)
+}
 
-	return &leagueCollection
+// LeagueDraftResults fetches the picks of the draft of the league
+// identified by leagueKey, in the order they were made. If withPlayers is
+// true, each pick includes the metadata of the drafted player, requested
+// with out=players in the same request.
+func (c *Client) LeagueDraftResults(ctx context.Context, leagueKey LeagueKey, withPlayers bool) ([]DraftResultResource, error) {
+	q := League(leagueKey.String()).DraftResults()
+	if withPlayers {
+		q = q.Out("players")
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.League == nil {
+		return nil, ErrNotReturned
+	}
+	return content.League.DraftResults, nil
 }
 
 // <?xml version="1.0" encoding="UTF-8"?>
@@ -1622,73 +1961,130 @@ func (y *YahooConfig) GetLeagueStandings(r *http.Request) *LeagueCollection {
 //   </league>
 // </fantasy_content>
 
+// TeamStandingsResource is a team's place in its league, included beneath
+// each team of a league's standings.
+type TeamStandingsResource struct {
+	XMLName                 xml.Name               `xml:"team_standings" json:"-"`
+	Rank                    int                    `xml:"rank" json:"rank,omitempty"`
+	PlayoffSeed             int                    `xml:"playoff_seed" json:"playoff_seed,omitempty"`
+	OutcomeTotals           *OutcomeTotalsResource `xml:"outcome_totals" json:"outcome_totals,omitempty"`
+	DivisionalOutcomeTotals *OutcomeTotalsResource `xml:"divisional_outcome_totals" json:"divisional_outcome_totals,omitempty"`
+	Streak                  *StreakResource        `xml:"streak" json:"streak,omitempty"`
+	PointsFor               Number                 `xml:"points_for" json:"points_for,omitempty"`
+	PointsAgainst           Number                 `xml:"points_against" json:"points_against,omitempty"`
+}
+
+// OutcomeTotalsResource is a team's record. Percentage is only reported
+// for the overall record.
+type OutcomeTotalsResource struct {
+	Wins       int    `xml:"wins" json:"wins"`
+	Losses     int    `xml:"losses" json:"losses"`
+	Ties       int    `xml:"ties" json:"ties"`
+	Percentage Number `xml:"percentage" json:"percentage,omitempty"`
+}
+
+// StreakResource is a team's current run of wins or losses.
+type StreakResource struct {
+	XMLName xml.Name `xml:"streak" json:"-"`
+	Type    string   `xml:"type" json:"type,omitempty"`
+	Value   int      `xml:"value" json:"value,omitempty"`
+}
+
+// ScoreBoardResource is a league's scoreboard for a week.
 type ScoreBoardResource struct {
-	XMLName       xml.Name `xml:"scoreboard",json:"-"`
-	Week  string   `xml:"week",json:",omitempty"`
-	Matchups  []MatchupResource   `xml:"matchups>matchup",json:",omitempty"`
+	XMLName  xml.Name          `xml:"scoreboard" json:"-"`
+	Week     int               `xml:"week" json:"week,omitempty"`
+	Matchups []MatchupResource `xml:"matchups>matchup" json:"matchups,omitempty"`
 }
 
+// MatchupResource is a head to head matchup between teams for a week.
 type MatchupResource struct {
-	XMLName       xml.Name `xml:"matchup",json:"-"`
-	Week  string   `xml:"week",json:",omitempty"`
-	Status  string   `xml:"status",json:",omitempty"`
-	IsTied  string   `xml:"is_tied",json:",omitempty"`
-	WinnerTeamKey  string   `xml:"winner_team_key",json:",omitempty"`
-	Teams  []TeamPointsResource   `xml:"teams>team",json:",omitempty"`
+	XMLName       xml.Name               `xml:"matchup" json:"-"`
+	Week          int                    `xml:"week" json:"week,omitempty"`
+	WeekStart     Date                   `xml:"week_start" json:"week_start"`
+	WeekEnd       Date                   `xml:"week_end" json:"week_end"`
+	Status        string                 `xml:"status" json:"status,omitempty"`
+	IsPlayoffs    bool                   `xml:"is_playoffs" json:"is_playoffs,omitempty"`
+	IsConsolation bool                   `xml:"is_consolation" json:"is_consolation,omitempty"`
+	IsTied        bool                   `xml:"is_tied" json:"is_tied,omitempty"`
+	WinnerTeamKey string                 `xml:"winner_team_key" json:"winner_team_key,omitempty"`
+	MatchupGrades []MatchupGradeResource `xml:"matchup_grades>matchup_grade" json:"matchup_grades,omitempty"`
+	Teams         []TeamResource         `xml:"teams>team" json:"teams,omitempty"`
+}
+
+// MatchupGradeResource is the letter grade Yahoo! gave a team's
+// performance in a matchup.
+type MatchupGradeResource struct {
+	XMLName xml.Name `xml:"matchup_grade" json:"-"`
+	TeamKey string   `xml:"team_key" json:"team_key,omitempty"`
+	Grade   string   `xml:"grade" json:"grade,omitempty"`
 }
 
 type TeamPointsResource struct {
-	XMLName       xml.Name `xml:"team_points",json:"-"`
-	CoverageType  string   `xml:"coverage_type",json:",omitempty"`
-	Week  string   `xml:"week",json:",omitempty"`
-	Total  string   `xml:"total",json:",omitempty"`
+	XMLName      xml.Name `xml:"team_points" json:"-"`
+	CoverageType string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int      `xml:"week" json:"week,omitempty"`
+	Season       int      `xml:"season" json:"season,omitempty"`
+	Total        float64  `xml:"total" json:"total,omitempty"`
 }
 
 type TeamProjectedPointsResource struct {
-	XMLName       xml.Name `xml:"team_projected_points",json:"-"`
-	CoverageType  string   `xml:"coverage_type",json:",omitempty"`
-	Week  string   `xml:"week",json:",omitempty"`
-	Total  string   `xml:"total",json:",omitempty"`
+	XMLName      xml.Name `xml:"team_projected_points" json:"-"`
+	CoverageType string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int      `xml:"week" json:"week,omitempty"`
+	Season       int      `xml:"season" json:"season,omitempty"`
+	Total        float64  `xml:"total" json:"total,omitempty"`
 }
 
-func (y *YahooConfig) GetLeagueScoreboard(r *http.Request) *LeagueCollection {
-	session, err := y.SessionStore.Get(r, "session-name")
-	if err != nil {
-		log.Println(err.Error(), 500)
-		return nil
-	}
+// TeamStatsResource is a team's stats for a week or season. Values are
+// left as Yahoo! formats them, since some, such as baseball's H/AB
+// ("12/30"), are not numbers.
+type TeamStatsResource struct {
+	XMLName      xml.Name       `xml:"team_stats" json:"-"`
+	CoverageType string         `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int            `xml:"week" json:"week,omitempty"`
+	Season       int            `xml:"season" json:"season,omitempty"`
+	Date         Date           `xml:"date" json:"date"`
+	Stats        []StatResource `xml:"stats>stat" json:"stats,omitempty"`
+}
 
-	tok, ok := session.Values["token"].(*oauth2.Token)
-	if !ok {
-		log.Println("error deserializing token from session")
-		return nil
-	}
-	client := y.conf.Client(oauth2.NoContext, tok)
+// StatResource is the value of a stat, identified by the stat_id of its
+// StatCategoryResource.
+type StatResource struct {
+	XMLName xml.Name `xml:"stat" json:"-"`
+	StatID  int      `xml:"stat_id" json:"stat_id"`
+	Value   string   `xml:"value" json:"value"`
+}
 
-	vars := mux.Vars(r)
-	league_keys := vars["league_keys"]
-	url := fmt.Sprintf("http://fantasysports.yahooapis.com/fantasy/v2/league/%s/scoreboard", league_keys)
-	req, err := http.NewRequest("GET", url, nil)
+// Scoreboard fetches the matchups of the league identified by leagueKey
+// for week, or for the current week if week is 0. Each team of a matchup
+// includes its points, projected points, win probability and stats.
+func (c *Client) Scoreboard(ctx context.Context, leagueKey LeagueKey, week int) (*ScoreBoardResource, error) {
+	q := League(leagueKey.String()).Scoreboard()
+	if week > 0 {
+		q = q.Param("week", strconv.Itoa(week))
+	}
+	content, err := c.Execute(ctx, q)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	if content.League == nil || content.League.ScoreBoard == nil {
+		return nil, ErrNotReturned
 	}
+	return content.League.ScoreBoard, nil
+}
 
-	res, err := client.Do(req)
+func (y *YahooConfig) GetLeagueScoreboard(r *http.Request) (*ScoreBoardResource, error) {
+	leagueKey, err := ParseLeagueKey(mux.Vars(r)["league_keys"])
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	body, err := ioutil.ReadAll(res.Body)
+	week, _ := strconv.Atoi(mux.Vars(r)["week"])
+	client, err := y.Client(r)
 	if err != nil {
-		log.Fatal(err)
-	}
-	defer res.Body.Close()
-	var leagueCollection LeagueCollection
-	if xml.Unmarshal(body, &leagueCollection); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	leagueCollection.Body = string(body)
-
-	return &leagueCollection
+	return client.Scoreboard(oauth2.NoContext, leagueKey, week)
 }
 
 
@@ -1772,53 +2168,163 @@ func (y *YahooConfig) GetLeagueScoreboard(r *http.Request) *LeagueCollection {
 // 						</team>
 // 					</teams>
 type TeamLogoResource struct {
-	XMLName xml.Name `xml:"team_logo",json:"-"`
-	Size    string   `xml:"size",json:",omitempty"`
-	URL     string   `xml:"url",json:",omitempty"`
+	XMLName xml.Name `xml:"team_logo" json:"-"`
+	Size    string   `xml:"size" json:"size,omitempty"`
+	URL     string   `xml:"url" json:"url,omitempty"`
 }
 
 type RosterAddsResource struct {
-	XMLName       xml.Name `xml:"roster_adds",json:"-"`
-	CoverageType  string   `xml:"coverage_type",json:",omitempty"`
-	CoverageValue string   `xml:"coverage_value",json:",omitempty"`
-	Value         string   `xml:"value",json:",omitempty"`
+	XMLName       xml.Name `xml:"roster_adds" json:"-"`
+	CoverageType  string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	CoverageValue int      `xml:"coverage_value" json:"coverage_value,omitempty"`
+	Value         int      `xml:"value" json:"value,omitempty"`
 }
 
 type ManagerResource struct {
-	XMLName        xml.Name `xml:"manager",json:"-"`
-	ManagerID      string   `xml:"manager_id",json:",omitempty"`
-	Nickname       string   `xml:"nickname",json:",omitempty"`
-	GUID           string   `xml:"guid",json:",omitempty"`
-	IsCommissioner string   `xml:"is_commissioner",json:",omitempty"`
-	IsCurrentLogin string   `xml:"is_current_login",json:",omitempty"`
-	Email          string   `xml:"email",json:",omitempty"`
-	ImageURL       string   `xml:"image_url",json:",omitempty"`
+	XMLName        xml.Name `xml:"manager" json:"-"`
+	ManagerID      int      `xml:"manager_id" json:"manager_id,omitempty"`
+	Nickname       string   `xml:"nickname" json:"nickname,omitempty"`
+	GUID           string   `xml:"guid" json:"guid,omitempty"`
+	IsCommissioner bool     `xml:"is_commissioner" json:"is_commissioner,omitempty"`
+	IsCurrentLogin bool     `xml:"is_current_login" json:"is_current_login,omitempty"`
+	Email          string   `xml:"email" json:"email,omitempty"`
+	ImageURL       string   `xml:"image_url" json:"image_url,omitempty"`
 }
 
 type TeamResource struct {
-	XMLName               xml.Name           `xml:"team",json:"-"`
-	TeamKey               string             `xml:"team_key",json:",omitempty"`
-	TeamID                string             `xml:"team_id",json:",omitempty"`
-	Name                  string             `xml:"name",json:",omitempty"`
-	IsOwnedByCurrentLogin string             `xml:"is_owned_by_current_login",json:",omitempty"`
-	URL                   string             `xml:"url",json:",omitempty"`
-	TeamLogos             []TeamLogoResource `xml:"team_logos>team_logo",omitempty"`
-	WaiverPriority        string             `xml:"waiver_priority",json:",omitempty"`
-	NumberOfMoves         string             `xml:"number_of_moves",json:",omitempty"`
-	NumberOfTrades        string             `xml:"number_of_trade",json:",omitempty"`
-	RosterAdds            RosterAddsResource `xml:"roster_adds",json:",omitempty"`
-	LeageScoringType      string             `xml:"league_scoring_type",json:",omitempty"`
-	Managers              []ManagerResource `xml:"managers>manager",json:",omitempty"`
-	TeamPoints						TeamPointsResource 	`xml:"team_points",json:",omitempty"`
-	TeamProjectedPoints TeamProjectedPointsResource`xml:"team_projected_points",json:",omitempty"`
+	XMLName               xml.Name                     `xml:"team" json:"-"`
+	TeamKey               string                       `xml:"team_key" json:"team_key,omitempty"`
+	TeamID                int                          `xml:"team_id" json:"team_id,omitempty"`
+	Name                  string                       `xml:"name" json:"name,omitempty"`
+	IsOwnedByCurrentLogin bool                         `xml:"is_owned_by_current_login" json:"is_owned_by_current_login,omitempty"`
+	URL                   string                       `xml:"url" json:"url,omitempty"`
+	TeamLogos             []TeamLogoResource           `xml:"team_logos>team_logo" json:"team_logos,omitempty"`
+	WaiverPriority        int                          `xml:"waiver_priority" json:"waiver_priority,omitempty"`
+	NumberOfMoves         int                          `xml:"number_of_moves" json:"number_of_moves,omitempty"`
+	NumberOfTrades        int                          `xml:"number_of_trades" json:"number_of_trades,omitempty"`
+	RosterAdds            *RosterAddsResource          `xml:"roster_adds" json:"roster_adds,omitempty"`
+	LeagueScoringType     string                       `xml:"league_scoring_type" json:"league_scoring_type,omitempty"`
+	Managers              []ManagerResource            `xml:"managers>manager" json:"managers,omitempty"`
+	TeamPoints            *TeamPointsResource          `xml:"team_points" json:"team_points,omitempty"`
+	TeamProjectedPoints   *TeamProjectedPointsResource `xml:"team_projected_points" json:"team_projected_points,omitempty"`
+	DivisionID            int                          `xml:"division_id" json:"division_id,omitempty"`
+	FAABBalance           int                          `xml:"faab_balance" json:"faab_balance,omitempty"`
+	ClinchedPlayoffs      bool                         `xml:"clinched_playoffs" json:"clinched_playoffs,omitempty"`
+	TeamStandings         *TeamStandingsResource       `xml:"team_standings" json:"team_standings,omitempty"`
+	WinProbability        float64                      `xml:"win_probability" json:"win_probability,omitempty"`
+	TeamStats             *TeamStatsResource           `xml:"team_stats" json:"team_stats,omitempty"`
+	Roster                *RosterResource              `xml:"roster" json:"roster,omitempty"`
+	DraftResults          []DraftResultResource        `xml:"draft_results>draft_result" json:"draft_results,omitempty"`
+	Matchups              []MatchupResource            `xml:"matchups>matchup" json:"matchups,omitempty"`
 }
 
 type TeamCollection struct {
-	XMLName xml.Name       `xml:"fantasy_content",json:"-"`
-	teams   []TeamResource `xml:"teams>team",json:",omitempty"`
+	XMLName xml.Name       `xml:"fantasy_content" json:"-"`
+	Teams   []TeamResource `xml:"teams>team" json:"teams,omitempty"`
 	//Body string
 }
 
+// DraftResultResource is a single pick of a league's draft. Cost is only
+// set for auction drafts, and Player only when the drafted players'
+// metadata was requested.
+type DraftResultResource struct {
+	XMLName   xml.Name        `xml:"draft_result" json:"-"`
+	Pick      int             `xml:"pick" json:"pick,omitempty"`
+	Round     int             `xml:"round" json:"round,omitempty"`
+	Cost      int             `xml:"cost" json:"cost,omitempty"`
+	TeamKey   string          `xml:"team_key" json:"team_key,omitempty"`
+	PlayerKey string          `xml:"player_key" json:"player_key,omitempty"`
+	Player    *PlayerResource `xml:"player" json:"player,omitempty"`
+}
+
+// TeamOption selects a sub-resource of a team for Client.Team to include.
+type TeamOption subResource
+
+// WithTeamStats includes the team's season stats and points.
+func WithTeamStats() TeamOption {
+	return TeamOption{name: "stats"}
+}
+
+// WithTeamWeekStats includes the team's stats and points for week, or for
+// the current week if week is 0.
+func WithTeamWeekStats(week int) TeamOption {
+	w := "current"
+	if week > 0 {
+		w = strconv.Itoa(week)
+	}
+	return TeamOption{name: "stats", params: []param{{"type", []string{"week"}}, {"week", []string{w}}}}
+}
+
+// WithTeamStandings includes the team's rank and record.
+func WithTeamStandings() TeamOption {
+	return TeamOption{name: "standings"}
+}
+
+// WithTeamRoster includes the team's current roster.
+func WithTeamRoster() TeamOption {
+	return TeamOption{name: "roster"}
+}
+
+// WithTeamDraftResults includes the players drafted by the team.
+func WithTeamDraftResults() TeamOption {
+	return TeamOption{name: "draftresults"}
+}
+
+// WithTeamMatchups includes the team's matchups for weeks, or all of its
+// matchups if no weeks are given.
+func WithTeamMatchups(weeks ...int) TeamOption {
+	o := TeamOption{name: "matchups"}
+	if len(weeks) > 0 {
+		w := make([]string, len(weeks))
+		for i, week := range weeks {
+			w[i] = strconv.Itoa(week)
+		}
+		o.params = []param{{"weeks", w}}
+	}
+	return o
+}
+
+// Team fetches the team identified by teamKey along with the sub-resources
+// selected by opts, all in a single request using the out parameter. The
+// parameters of the selected sub-resources are shared, so it is an error
+// to select, for example, the stats of two different weeks, or both
+// WithTeamStats and WithTeamWeekStats.
+func (c *Client) Team(ctx context.Context, teamKey TeamKey, opts ...TeamOption) (*TeamResource, error) {
+	subs := make([]subResource, len(opts))
+	for i, o := range opts {
+		subs[i] = subResource(o)
+	}
+	q, err := Team(teamKey.String()).withSubResources(subs)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.Team == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Team, nil
+}
+
+// TeamDraftResults fetches the picks made by the team identified by
+// teamKey, in the same way as LeagueDraftResults.
+func (c *Client) TeamDraftResults(ctx context.Context, teamKey TeamKey, withPlayers bool) ([]DraftResultResource, error) {
+	q := Team(teamKey.String()).DraftResults()
+	if withPlayers {
+		q = q.Out("players")
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.Team == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Team.DraftResults, nil
+}
+
 /*
 HTTP Operations Supported
 
@@ -3016,6 +3522,163 @@ MLB, NBA, or NHL:
 </fantasy_content>
 */
 
+// RosterResource is the players on a team for a week, in NFL, or for a
+// date, in MLB, NBA and NHL.
+type RosterResource struct {
+	XMLName      xml.Name         `xml:"roster" json:"-"`
+	CoverageType string           `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int              `xml:"week" json:"week,omitempty"`
+	Date         Date             `xml:"date" json:"date"`
+	IsEditable   bool             `xml:"is_editable" json:"is_editable,omitempty"`
+	Players      []PlayerResource `xml:"players>player" json:"players,omitempty"`
+}
+
+// SelectedPositionResource is the position a player fills on a roster,
+// such as "QB", or "BN" for the bench.
+type SelectedPositionResource struct {
+	XMLName      xml.Name `xml:"selected_position" json:"-"`
+	CoverageType string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int      `xml:"week" json:"week,omitempty"`
+	Date         Date     `xml:"date" json:"date"`
+	Position     string   `xml:"position" json:"position,omitempty"`
+	IsFlex       bool     `xml:"is_flex" json:"is_flex,omitempty"`
+}
+
+// StartingStatusResource reports whether a player is in their real team's
+// starting lineup, as reported for MLB and NHL.
+type StartingStatusResource struct {
+	XMLName      xml.Name `xml:"starting_status" json:"-"`
+	CoverageType string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int      `xml:"week" json:"week,omitempty"`
+	Date         Date     `xml:"date" json:"date"`
+	IsStarting   bool     `xml:"is_starting" json:"is_starting"`
+}
+
+// RosterAt selects the roster of a week, in NFL, or of a date, in MLB, NBA
+// and NHL. The zero RosterAt selects the current roster.
+type RosterAt struct {
+	Week int
+	Date Date
+}
+
+// RosterWeek returns the RosterAt selecting the roster of week.
+func RosterWeek(week int) RosterAt {
+	return RosterAt{Week: week}
+}
+
+// RosterDate returns the RosterAt selecting the roster of the day of t.
+func RosterDate(t time.Time) RosterAt {
+	return RosterAt{Date: Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}}
+}
+
+// apply adds the week or date parameter selecting at to the roster at the
+// end of q.
+func (at RosterAt) apply(q *Query) (*Query, error) {
+	switch {
+	case at.Week < 0:
+		return nil, fmt.Errorf("yahooapi: invalid roster week %d", at.Week)
+	case at.Week > 0 && !at.Date.IsZero():
+		return nil, fmt.Errorf("yahooapi: a roster is selected by either week or date")
+	case at.Week > 0:
+		return q.Param("week", strconv.Itoa(at.Week)), nil
+	case !at.Date.IsZero():
+		return q.Param("date", at.Date.String()), nil
+	}
+	return q, nil
+}
+
+// Roster fetches the roster of the team identified by teamKey at the week
+// or date selected by at, including each player's selected position,
+// eligible positions, starting status and injury status.
+func (c *Client) Roster(ctx context.Context, teamKey TeamKey, at RosterAt) (*RosterResource, error) {
+	q, err := at.apply(Team(teamKey.String()).Roster())
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.Team == nil || content.Team.Roster == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Team.Roster, nil
+}
+
+// PlayerPosition moves a player to a position of a roster, such as "WR", or
+// to the bench with "BN".
+type PlayerPosition struct {
+	PlayerKey PlayerKey
+	Position  string
+}
+
+// rosterUpdate is the document PUT to a roster to change its lineup.
+type rosterUpdate struct {
+	XMLName      xml.Name               `xml:"fantasy_content"`
+	CoverageType string                 `xml:"roster>coverage_type"`
+	Week         int                    `xml:"roster>week,omitempty"`
+	Date         string                 `xml:"roster>date,omitempty"`
+	Players      []rosterUpdatePosition `xml:"roster>players>player"`
+}
+
+type rosterUpdatePosition struct {
+	PlayerKey string `xml:"player_key"`
+	Position  string `xml:"position"`
+}
+
+// SetLineup moves the players listed in positions on the roster of the
+// team identified by teamKey for the week, in NFL, or date, in MLB, NBA and
+// NHL, selected by coverage; players not listed keep their positions. If
+// any move is invalid, no change is made and the *APIError returned
+// carries Yahoo!'s explanation in its Description. Otherwise the updated
+// roster is fetched and returned; if that fails, the change has still been
+// made and the error is a *RefetchError.
+func (c *Client) SetLineup(ctx context.Context, teamKey TeamKey, coverage RosterAt, positions []PlayerPosition) (*RosterResource, error) {
+	if _, err := coverage.apply(Team(teamKey.String()).Roster()); err != nil {
+		return nil, err
+	}
+	doc := rosterUpdate{Week: coverage.Week}
+	switch {
+	case coverage.Week > 0:
+		doc.CoverageType = "week"
+	case !coverage.Date.IsZero():
+		doc.CoverageType = "date"
+		doc.Date = coverage.Date.String()
+	default:
+		return nil, fmt.Errorf("yahooapi: a lineup change requires a week or date")
+	}
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("yahooapi: a lineup change requires at least one player")
+	}
+	for _, p := range positions {
+		key := p.PlayerKey.String()
+		if key == "" || p.Position == "" {
+			return nil, fmt.Errorf("yahooapi: a lineup change requires a player key and position for each player")
+		}
+		doc.Players = append(doc.Players, rosterUpdatePosition{key, p.Position})
+	}
+	b, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := Team(teamKey.String()).Roster().Path()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.send(ctx, "PUT", path, append([]byte(xml.Header), b...)); err != nil {
+		return nil, err
+	}
+	// League resources such as the scoreboard include the team's lineup,
+	// so they are as stale as the team's own.
+	c.InvalidateLeague(teamKey.League())
+	roster, err := c.Roster(ctx, teamKey, coverage)
+	if err != nil {
+		return nil, &RefetchError{err}
+	}
+	return roster, nil
+}
+
 /*
 Teams collection¶
 
@@ -3263,6 +3926,219 @@ http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431/players;player_ke
 </fantasy_content>
 */
 
+// PlayerResource is a player (athlete) within a game, or within a league
+// when requested in a league's context.
+type PlayerResource struct {
+	XMLName               xml.Name          `xml:"player" json:"-"`
+	PlayerKey             string            `xml:"player_key" json:"player_key,omitempty"`
+	PlayerID              int               `xml:"player_id" json:"player_id,omitempty"`
+	Name                  PlayerName        `xml:"name" json:"name"`
+	Status                string            `xml:"status" json:"status,omitempty"`
+	StatusFull            string            `xml:"status_full" json:"status_full,omitempty"`
+	InjuryNote            string            `xml:"injury_note" json:"injury_note,omitempty"`
+	OnDisabledList        bool              `xml:"on_disabled_list" json:"on_disabled_list,omitempty"`
+	EditorialPlayerKey    string            `xml:"editorial_player_key" json:"editorial_player_key,omitempty"`
+	EditorialTeamKey      string            `xml:"editorial_team_key" json:"editorial_team_key,omitempty"`
+	EditorialTeamFullName string            `xml:"editorial_team_full_name" json:"editorial_team_full_name,omitempty"`
+	EditorialTeamAbbr     string            `xml:"editorial_team_abbr" json:"editorial_team_abbr,omitempty"`
+	ByeWeeks              []int             `xml:"bye_weeks>week" json:"bye_weeks,omitempty"`
+	UniformNumber         string            `xml:"uniform_number" json:"uniform_number,omitempty"`
+	DisplayPosition       string            `xml:"display_position" json:"display_position,omitempty"`
+	Headshot              *HeadshotResource `xml:"headshot" json:"headshot,omitempty"`
+	ImageURL              string            `xml:"image_url" json:"image_url,omitempty"`
+	IsUndroppable         bool              `xml:"is_undroppable" json:"is_undroppable,omitempty"`
+	PositionType          string            `xml:"position_type" json:"position_type,omitempty"`
+	EligiblePositions     []string          `xml:"eligible_positions>position" json:"eligible_positions,omitempty"`
+	HasPlayerNotes        bool              `xml:"has_player_notes" json:"has_player_notes,omitempty"`
+	HasRecentPlayerNotes  bool              `xml:"has_recent_player_notes" json:"has_recent_player_notes,omitempty"`
+
+	// Sub-resources, only present when requested.
+	PlayerStats   *PlayerStatsResource   `xml:"player_stats" json:"player_stats,omitempty"`
+	PlayerPoints  *PlayerPointsResource  `xml:"player_points" json:"player_points,omitempty"`
+	Ownership     *OwnershipResource     `xml:"ownership" json:"ownership,omitempty"`
+	PercentOwned  *PercentOwnedResource  `xml:"percent_owned" json:"percent_owned,omitempty"`
+	DraftAnalysis *DraftAnalysisResource `xml:"draft_analysis" json:"draft_analysis,omitempty"`
+
+	// TransactionData is the move of the player, only present for the
+	// players of a transaction.
+	TransactionData *TransactionDataResource `xml:"transaction_data" json:"transaction_data,omitempty"`
+
+	// Roster fields, only present for the players of a roster.
+	SelectedPosition *SelectedPositionResource `xml:"selected_position" json:"selected_position,omitempty"`
+	StartingStatus   *StartingStatusResource   `xml:"starting_status" json:"starting_status,omitempty"`
+	IsEditable       bool                      `xml:"is_editable" json:"is_editable,omitempty"`
+}
+
+// PlayerName is the name of a player, in full and in parts.
+type PlayerName struct {
+	Full       string `xml:"full" json:"full,omitempty"`
+	First      string `xml:"first" json:"first,omitempty"`
+	Last       string `xml:"last" json:"last,omitempty"`
+	ASCIIFirst string `xml:"ascii_first" json:"ascii_first,omitempty"`
+	ASCIILast  string `xml:"ascii_last" json:"ascii_last,omitempty"`
+}
+
+// HeadshotResource is a player's photo.
+type HeadshotResource struct {
+	XMLName xml.Name `xml:"headshot" json:"-"`
+	URL     string   `xml:"url" json:"url,omitempty"`
+	Size    string   `xml:"size" json:"size,omitempty"`
+}
+
+// PlayerStatsResource is a player's stats for a season, week or date.
+// Values are left as Yahoo! formats them, as for TeamStatsResource.
+type PlayerStatsResource struct {
+	XMLName      xml.Name       `xml:"player_stats" json:"-"`
+	CoverageType string         `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int            `xml:"week" json:"week,omitempty"`
+	Season       int            `xml:"season" json:"season,omitempty"`
+	Date         Date           `xml:"date" json:"date"`
+	Stats        []StatResource `xml:"stats>stat" json:"stats,omitempty"`
+}
+
+// PlayerPointsResource is the fantasy points a player scored in a league
+// for a season, week or date.
+type PlayerPointsResource struct {
+	XMLName      xml.Name `xml:"player_points" json:"-"`
+	CoverageType string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int      `xml:"week" json:"week,omitempty"`
+	Season       int      `xml:"season" json:"season,omitempty"`
+	Date         Date     `xml:"date" json:"date"`
+	Total        float64  `xml:"total" json:"total,omitempty"`
+}
+
+// OwnershipResource is whether a player is owned by a team of a league,
+// on waivers or a free agent.
+type OwnershipResource struct {
+	XMLName       xml.Name `xml:"ownership" json:"-"`
+	OwnershipType string   `xml:"ownership_type" json:"ownership_type,omitempty"`
+	OwnerTeamKey  string   `xml:"owner_team_key" json:"owner_team_key,omitempty"`
+	OwnerTeamName string   `xml:"owner_team_name" json:"owner_team_name,omitempty"`
+	WaiverDate    Date     `xml:"waiver_date" json:"waiver_date"`
+}
+
+// PercentOwnedResource is the percentage of leagues a player is owned in,
+// and its change over the coverage period.
+type PercentOwnedResource struct {
+	XMLName      xml.Name `xml:"percent_owned" json:"-"`
+	CoverageType string   `xml:"coverage_type" json:"coverage_type,omitempty"`
+	Week         int      `xml:"week" json:"week,omitempty"`
+	Date         Date     `xml:"date" json:"date"`
+	Value        Number   `xml:"value" json:"value"`
+	Delta        Number   `xml:"delta" json:"delta"`
+}
+
+// DraftAnalysisResource is how early, and how often, a player is drafted
+// across Yahoo! leagues. Players who are never drafted have zero values.
+type DraftAnalysisResource struct {
+	XMLName        xml.Name `xml:"draft_analysis" json:"-"`
+	AveragePick    Number   `xml:"average_pick" json:"average_pick"`
+	AverageRound   Number   `xml:"average_round" json:"average_round"`
+	AverageCost    Number   `xml:"average_cost" json:"average_cost,omitempty"`
+	PercentDrafted Number   `xml:"percent_drafted" json:"percent_drafted"`
+}
+
+// PlayerOption selects a sub-resource of a player for Client.Player and
+// Client.LeaguePlayer to include.
+type PlayerOption subResource
+
+// WithPlayerStats includes the player's stats for the current season.
+func WithPlayerStats() PlayerOption {
+	return PlayerOption{name: "stats"}
+}
+
+// WithPlayerSeasonStats includes the player's stats for season.
+func WithPlayerSeasonStats(season int) PlayerOption {
+	return PlayerOption{name: "stats", params: []param{{"type", []string{"season"}}, {"season", []string{strconv.Itoa(season)}}}}
+}
+
+// WithPlayerWeekStats includes the player's stats for week, or for the
+// current week if week is 0.
+func WithPlayerWeekStats(week int) PlayerOption {
+	w := "current"
+	if week > 0 {
+		w = strconv.Itoa(week)
+	}
+	return PlayerOption{name: "stats", params: []param{{"type", []string{"week"}}, {"week", []string{w}}}}
+}
+
+// WithPlayerDateStats includes the player's stats for the day of t.
+func WithPlayerDateStats(t time.Time) PlayerOption {
+	return PlayerOption{name: "stats", params: []param{{"type", []string{"date"}}, {"date", []string{t.Format(dateLayout)}}}}
+}
+
+// WithPlayerOwnership includes whether the player is owned by a team of
+// the league, on waivers or a free agent. It is only valid for
+// Client.LeaguePlayer.
+func WithPlayerOwnership() PlayerOption {
+	return PlayerOption{name: "ownership"}
+}
+
+// WithPlayerPercentOwned includes the percentage of leagues the player is
+// owned in.
+func WithPlayerPercentOwned() PlayerOption {
+	return PlayerOption{name: "percent_owned"}
+}
+
+// WithPlayerDraftAnalysis includes the player's average pick, average
+// round and percent drafted.
+func WithPlayerDraftAnalysis() PlayerOption {
+	return PlayerOption{name: "draft_analysis"}
+}
+
+func playerSubResources(opts []PlayerOption) []subResource {
+	subs := make([]subResource, len(opts))
+	for i, o := range opts {
+		subs[i] = subResource(o)
+	}
+	return subs
+}
+
+// Player fetches the player identified by playerKey along with the
+// sub-resources selected by opts, in a single request. Ownership is only
+// known within a league, so use LeaguePlayer to include it.
+func (c *Client) Player(ctx context.Context, playerKey PlayerKey, opts ...PlayerOption) (*PlayerResource, error) {
+	for _, o := range opts {
+		if o.name == "ownership" {
+			return nil, fmt.Errorf("yahooapi: ownership is only available within a league")
+		}
+	}
+	q, err := Player(playerKey.String()).withSubResources(playerSubResources(opts))
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.Player == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Player, nil
+}
+
+// LeaguePlayer fetches the player identified by playerKey in the context
+// of the league identified by leagueKey, so that stats include the
+// league's fantasy points, along with the sub-resources selected by opts.
+func (c *Client) LeaguePlayer(ctx context.Context, leagueKey LeagueKey, playerKey PlayerKey, opts ...PlayerOption) (*PlayerResource, error) {
+	q, err := League(leagueKey.String()).Players().PlayerKeys(playerKey.String()).withSubResources(playerSubResources(opts))
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.League != nil {
+		for i := range content.League.Players {
+			if sameKey(playerKey.String(), content.League.Players[i].PlayerKey) {
+				return &content.League.Players[i], nil
+			}
+		}
+	}
+	return nil, ErrNotReturned
+}
+
 /*
 Players collection¶
 
@@ -3386,6 +4262,222 @@ start	Any integer 0 or greater	/players;start=25
 count	Any integer greater than 0	/players;count=5
 */
 
+// PlayerStatus restricts a league's players collection by ownership.
+type PlayerStatus string
+
+const (
+	// AvailablePlayers are the players not on any team of the league, both
+	// free agents and those on waivers.
+	AvailablePlayers PlayerStatus = "A"
+
+	// FreeAgents are the available players who can be added right away.
+	FreeAgents PlayerStatus = "FA"
+
+	// WaiverPlayers are the available players who can only be claimed
+	// off waivers.
+	WaiverPlayers PlayerStatus = "W"
+
+	// TakenPlayers are the players on a team of the league.
+	TakenPlayers PlayerStatus = "T"
+
+	// KeeperPlayers are the players kept by their teams from the previous
+	// season.
+	KeeperPlayers PlayerStatus = "K"
+)
+
+// Sorts of a players collection other than by a stat_id.
+const (
+	SortByName        = "NAME" // by last name
+	SortByOverallRank = "OR"   // by preseason rank
+	SortByActualRank  = "AR"   // by rank over SortType
+	SortByPoints      = "PTS"  // by fantasy points over SortType
+)
+
+// PlayerSortType is the period a players collection is sorted over.
+type PlayerSortType string
+
+const (
+	// SortSeason sorts over the season given by PlayerFilter.SortSeason,
+	// or the current one.
+	SortSeason PlayerSortType = "season"
+
+	// SortDate sorts over the day given by PlayerFilter.SortDate, in
+	// daily games such as baseball.
+	SortDate PlayerSortType = "date"
+
+	// SortWeek sorts over the week given by PlayerFilter.SortWeek, in
+	// football.
+	SortWeek PlayerSortType = "week"
+
+	// SortLastWeek and SortLastMonth sort over the last seven or thirty
+	// days.
+	SortLastWeek  PlayerSortType = "lastweek"
+	SortLastMonth PlayerSortType = "lastmonth"
+)
+
+// PlayerFilter restricts and orders a players collection. For instance,
+// the ten free agent quarterbacks with the most points last week are
+//
+//   &PlayerFilter{Position: "QB", Status: FreeAgents, Sort: SortByPoints, SortType: SortLastWeek, Count: 10}
+//
+// Yahoo! only applies filters other than Start and Count to the players of
+// a league, so they are rejected elsewhere.
+type PlayerFilter struct {
+	// Position and Status only include players eligible at a position,
+	// such as "QB", or of an ownership status.
+	Position string
+	Status   PlayerStatus
+
+	// Search matches players by name.
+	Search string
+
+	// Sort is a stat_id, such as "60", or one of SortByName,
+	// SortByOverallRank, SortByActualRank and SortByPoints.
+	Sort string
+
+	// SortType is the period Sort is computed over. If empty, it follows
+	// from whichever of SortSeason, SortDate or SortWeek is set.
+	SortType   PlayerSortType
+	SortSeason int
+	SortDate   Date
+	SortWeek   int
+
+	// Start and Count select a page of the collection, starting from
+	// offset Start. Yahoo! returns at most PlayersPageSize players at a time.
+	Start int
+	Count int
+}
+
+// inLeague reports whether f uses filters that Yahoo! only applies to the
+// players of a league.
+func (f *PlayerFilter) inLeague() bool {
+	return f.Position != "" || f.Status != "" || f.Search != "" || f.Sort != "" ||
+		f.SortType != "" || f.SortSeason != 0 || !f.SortDate.IsZero() || f.SortWeek != 0
+}
+
+// apply adds the parameters for f to the players collection at the end of
+// q. A nil filter leaves q unchanged.
+func (f *PlayerFilter) apply(q *Query) (*Query, error) {
+	if f == nil {
+		return q, nil
+	}
+	if f.inLeague() {
+		n := len(q.segments)
+		if n < 2 || q.segments[n-1].name != "players" || (q.segments[n-2].name != "league" && q.segments[n-2].name != "leagues") {
+			return nil, fmt.Errorf("yahooapi: player filters other than start and count only apply to the players of a league")
+		}
+	}
+
+	if f.Position != "" {
+		q = q.Param("position", f.Position)
+	}
+	switch f.Status {
+	case "":
+	case AvailablePlayers, FreeAgents, WaiverPlayers, TakenPlayers, KeeperPlayers:
+		q = q.Param("status", string(f.Status))
+	default:
+		return nil, fmt.Errorf("yahooapi: invalid player status %q", f.Status)
+	}
+	if f.Search != "" {
+		q = q.Param("search", f.Search)
+	}
+	if f.Sort != "" {
+		switch f.Sort {
+		case SortByName, SortByOverallRank, SortByActualRank, SortByPoints:
+		default:
+			if !isNumber(f.Sort) {
+				return nil, fmt.Errorf("yahooapi: invalid player sort %q", f.Sort)
+			}
+		}
+		q = q.Param("sort", f.Sort)
+	}
+
+	sortType := f.SortType
+	var periods []PlayerSortType
+	if f.SortSeason != 0 {
+		periods = append(periods, SortSeason)
+	}
+	if !f.SortDate.IsZero() {
+		periods = append(periods, SortDate)
+	}
+	if f.SortWeek != 0 {
+		periods = append(periods, SortWeek)
+	}
+	switch {
+	case len(periods) > 1:
+		return nil, fmt.Errorf("yahooapi: only one of sort_season, sort_date and sort_week may be set")
+	case len(periods) == 1 && sortType == "":
+		sortType = periods[0]
+	case len(periods) == 1 && sortType != periods[0]:
+		return nil, fmt.Errorf("yahooapi: sort_%s requires sort_type=%s", periods[0], periods[0])
+	}
+	switch sortType {
+	case "":
+	case SortSeason, SortDate, SortWeek, SortLastWeek, SortLastMonth:
+		q = q.Param("sort_type", string(sortType))
+	default:
+		return nil, fmt.Errorf("yahooapi: invalid player sort type %q", sortType)
+	}
+	switch {
+	case f.SortSeason != 0:
+		q = q.Param("sort_season", strconv.Itoa(f.SortSeason))
+	case !f.SortDate.IsZero():
+		q = q.Param("sort_date", f.SortDate.String())
+	case f.SortWeek < 0:
+		return nil, fmt.Errorf("yahooapi: invalid sort week %d", f.SortWeek)
+	case f.SortWeek > 0:
+		q = q.Param("sort_week", strconv.Itoa(f.SortWeek))
+	}
+
+	if f.Start < 0 {
+		return nil, fmt.Errorf("yahooapi: invalid start %d", f.Start)
+	}
+	if f.Start > 0 {
+		q = q.Param("start", strconv.Itoa(f.Start))
+	}
+	if f.Count < 0 {
+		return nil, fmt.Errorf("yahooapi: invalid count %d", f.Count)
+	}
+	if f.Count > 0 {
+		q = q.Param("count", strconv.Itoa(f.Count))
+	}
+	return q, nil
+}
+
+// LeaguePlayers fetches a single page of the players of the league
+// identified by leagueKey, restricted and ordered by filter, which may be
+// nil. Use IterateLeaguePlayers to page through all of them.
+func (c *Client) LeaguePlayers(ctx context.Context, leagueKey LeagueKey, filter *PlayerFilter) ([]PlayerResource, error) {
+	q, err := filter.apply(League(leagueKey.String()).Players())
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.League == nil {
+		return nil, ErrNotReturned
+	}
+	return content.League.Players, nil
+}
+
+// IterateLeaguePlayers returns an iterator over all the players of the
+// league identified by leagueKey that match filter, which may be nil. The
+// Start and Count of filter are ignored.
+func (c *Client) IterateLeaguePlayers(ctx context.Context, leagueKey LeagueKey, filter *PlayerFilter) *PlayerIterator {
+	if filter != nil {
+		f := *filter
+		f.Start, f.Count = 0, 0
+		filter = &f
+	}
+	q, err := filter.apply(League(leagueKey.String()).Players())
+	if err != nil {
+		return &PlayerIterator{err: err}
+	}
+	return c.IteratePlayers(ctx, q)
+}
+
 /*
 Transaction resource¶
 
@@ -3592,6 +4684,59 @@ http://fantasysports.yahooapis.com/fantasy/v2/transaction/257.l.193.pt.1 - Pendi
 </fantasy_content>
 */
 
+// TransactionResource is an add, drop, trade or commissioner change in a
+// league, or a pending waiver claim or trade. Which fields are set depends
+// on Type: waiver claims carry the Waiver fields and FAABBid, and trades
+// the Trade fields.
+type TransactionResource struct {
+	XMLName        xml.Name  `xml:"transaction" json:"-"`
+	TransactionKey string    `xml:"transaction_key" json:"transaction_key,omitempty"`
+	TransactionID  int       `xml:"transaction_id" json:"transaction_id,omitempty"`
+	Type           string    `xml:"type" json:"type,omitempty"`
+	Status         string    `xml:"status" json:"status,omitempty"`
+	Timestamp      Timestamp `xml:"timestamp" json:"timestamp"`
+	FAABBid        int       `xml:"faab_bid" json:"faab_bid,omitempty"`
+
+	WaiverPlayerKey string `xml:"waiver_player_key" json:"waiver_player_key,omitempty"`
+	WaiverTeamKey   string `xml:"waiver_team_key" json:"waiver_team_key,omitempty"`
+	WaiverDate      Date   `xml:"waiver_date" json:"waiver_date"`
+	WaiverPriority  int    `xml:"waiver_priority" json:"waiver_priority,omitempty"`
+
+	TraderTeamKey     string    `xml:"trader_team_key" json:"trader_team_key,omitempty"`
+	TradeeTeamKey     string    `xml:"tradee_team_key" json:"tradee_team_key,omitempty"`
+	TradeProposedTime Timestamp `xml:"trade_proposed_time" json:"trade_proposed_time"`
+	TradeNote         string    `xml:"trade_note" json:"trade_note,omitempty"`
+
+	Players []PlayerResource `xml:"players>player" json:"players,omitempty"`
+}
+
+// TransactionDataResource is the move of a single player in a
+// transaction: where they came from and where they went.
+type TransactionDataResource struct {
+	XMLName             xml.Name `xml:"transaction_data" json:"-"`
+	Type                string   `xml:"type" json:"type,omitempty"`
+	SourceType          string   `xml:"source_type" json:"source_type,omitempty"`
+	SourceTeamKey       string   `xml:"source_team_key" json:"source_team_key,omitempty"`
+	SourceTeamName      string   `xml:"source_team_name" json:"source_team_name,omitempty"`
+	DestinationType     string   `xml:"destination_type" json:"destination_type,omitempty"`
+	DestinationTeamKey  string   `xml:"destination_team_key" json:"destination_team_key,omitempty"`
+	DestinationTeamName string   `xml:"destination_team_name" json:"destination_team_name,omitempty"`
+}
+
+// Transaction fetches the completed transaction, waiver claim or pending
+// trade identified by key, including the players it moves and where each
+// one comes from and goes to.
+func (c *Client) Transaction(ctx context.Context, key TransactionKey) (*TransactionResource, error) {
+	content, err := c.Execute(ctx, Transaction(key.String()).Players())
+	if err != nil {
+		return nil, err
+	}
+	if content.Transaction == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Transaction, nil
+}
+
 // PUT
 // Using PUT, you may edit the waiver priority or FAAB bid for any of your
 // pending waiver claims. You can also accept or reject trades that have been
@@ -3732,7 +4877,10 @@ func (y *YahooConfig) DeletePendingTrade() {
 //
 // You can also POST to the API to perform operations like adding and/or
 // dropping players to/from a team and proposing trades.
-type TransactionCollection struct{}
+type TransactionCollection struct {
+	XMLName      xml.Name              `xml:"fantasy_content" json:"-"`
+	Transactions []TransactionResource `xml:"transactions>transaction" json:"transactions,omitempty"`
+}
 
 // GetTransactionCollection
 //
@@ -3772,10 +4920,59 @@ type TransactionCollection struct{}
 // These filters can be combined to obtain a more restricted list of
 // transactions.
 //
-//
-func (y *YahooConfig) GetTransactionCollection() *TransactionCollection {
-	panic("Not Implemented")
-	return nil
+// GetTransactionCollection fetches the transactions named by the
+// transaction_keys route variable, or else those of the leagues named by
+// league_keys. The type, team_key and count query parameters filter the
+// transactions of leagues, with type accepting a comma separated list.
+func (y *YahooConfig) GetTransactionCollection(r *http.Request) (*TransactionCollection, error) {
+	vars := mux.Vars(r)
+	client, err := y.Client(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if s := vars["transaction_keys"]; s != "" {
+		keys, err := parseTransactionKeys(s)
+		if err != nil {
+			return nil, err
+		}
+		transactions, err := client.Transactions(oauth2.NoContext, keys...)
+		if err != nil {
+			return nil, err
+		}
+		return &TransactionCollection{Transactions: transactions}, nil
+	}
+
+	leagueKeys, err := parseLeagueKeys(vars["league_keys"])
+	if err != nil {
+		return nil, err
+	}
+	filter := &TransactionsFilter{}
+	query := r.URL.Query()
+	if s := query.Get("type"); s != "" {
+		for _, t := range strings.Split(s, ",") {
+			filter.Types = append(filter.Types, TransactionType(t))
+		}
+	}
+	if s := query.Get("team_key"); s != "" {
+		if filter.TeamKey, err = ParseTeamKey(s); err != nil {
+			return nil, err
+		}
+	}
+	if s := query.Get("count"); s != "" {
+		if filter.Count, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("yahooapi: invalid count %q", s)
+		}
+	}
+	leagues, err := client.LeaguesTransactions(oauth2.NoContext, leagueKeys, filter)
+	if err != nil {
+		return nil, err
+	}
+	var collection TransactionCollection
+	for _, league := range leagues {
+		collection.Transactions = append(collection.Transactions, league.Transactions...)
+	}
+	return &collection, nil
 }
 
 /*
@@ -3795,6 +4992,138 @@ type with team_key	waiver,pending_trade	You can only use these options when also
 count	Any integer greater than 0	/transactions;count=5
 */
 
+// TransactionType is the type of a transaction, used to filter transactions
+// collections.
+type TransactionType string
+
+const (
+	// AddTransaction and DropTransaction add a player to, or drop one
+	// from, a team.
+	AddTransaction  TransactionType = "add"
+	DropTransaction TransactionType = "drop"
+
+	// CommishTransaction is a change made by the league's commissioner.
+	CommishTransaction TransactionType = "commish"
+
+	// TradeTransaction is a completed trade between two teams.
+	TradeTransaction TransactionType = "trade"
+
+	// WaiverTransaction is a pending claim of a player on waivers.
+	WaiverTransaction TransactionType = "waiver"
+
+	// PendingTradeTransaction is a trade that has been proposed but not
+	// yet accepted, rejected or vetoed.
+	PendingTradeTransaction TransactionType = "pending_trade"
+
+	// AddDropTransaction is the type of a transaction replacing one player
+	// with another. It is not accepted as a filter.
+	AddDropTransaction TransactionType = "add/drop"
+)
+
+// TransactionsFilter restricts a league's transactions collection to the
+// transactions matching all of its non-zero fields. Waivers and pending
+// trades are only visible to the teams involved, so filtering by
+// WaiverTransaction or PendingTradeTransaction requires TeamKey.
+type TransactionsFilter struct {
+	// Types only includes transactions of the listed types.
+	Types []TransactionType
+
+	// TeamKey only includes transactions involving the team.
+	TeamKey TeamKey
+
+	// Count limits the collection to the most recent transactions.
+	Count int
+}
+
+// apply adds the parameters for f to the transactions collection at the
+// end of q. A nil filter leaves q unchanged.
+func (f *TransactionsFilter) apply(q *Query) (*Query, error) {
+	if f == nil {
+		return q, nil
+	}
+	teamKey := f.TeamKey.String()
+	types := make([]string, len(f.Types))
+	for i, t := range f.Types {
+		switch t {
+		case AddTransaction, DropTransaction, CommishTransaction, TradeTransaction:
+		case WaiverTransaction, PendingTradeTransaction:
+			if teamKey == "" {
+				return nil, fmt.Errorf("yahooapi: filtering transactions by %s requires a team key", t)
+			}
+		default:
+			return nil, fmt.Errorf("yahooapi: invalid transaction type %q", t)
+		}
+		types[i] = string(t)
+	}
+	switch len(types) {
+	case 0:
+	case 1:
+		q = q.Param("type", types[0])
+	default:
+		q = q.Param("types", types...)
+	}
+	if teamKey != "" {
+		q = q.Param("team_key", teamKey)
+	}
+	if f.Count < 0 {
+		return nil, fmt.Errorf("yahooapi: invalid count %d", f.Count)
+	}
+	if f.Count > 0 {
+		q = q.Param("count", strconv.Itoa(f.Count))
+	}
+	return q, nil
+}
+
+// Transactions fetches the transactions identified by keys, including the
+// players each one moved.
+func (c *Client) Transactions(ctx context.Context, keys ...TransactionKey) ([]TransactionResource, error) {
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k.String()
+	}
+	content, err := c.Execute(ctx, Transactions().TransactionKeys(s...))
+	if err != nil {
+		return nil, err
+	}
+	return content.Transactions, nil
+}
+
+// LeagueTransactions fetches the transactions of the league identified by
+// leagueKey, most recent first, restricted by filter, which may be nil.
+func (c *Client) LeagueTransactions(ctx context.Context, leagueKey LeagueKey, filter *TransactionsFilter) ([]TransactionResource, error) {
+	q, err := filter.apply(League(leagueKey.String()).Transactions())
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if content.League == nil {
+		return nil, ErrNotReturned
+	}
+	return content.League.Transactions, nil
+}
+
+// LeaguesTransactions fetches the transactions of the leagues identified by
+// leagueKeys in a single request, restricted by filter, which may be nil.
+// Each league returned has its Transactions filled in.
+func (c *Client) LeaguesTransactions(ctx context.Context, leagueKeys []LeagueKey, filter *TransactionsFilter) ([]LeagueResource, error) {
+	s := make([]string, len(leagueKeys))
+	for i, k := range leagueKeys {
+		s[i] = k.String()
+	}
+	q, err := filter.apply(Leagues().LeagueKeys(s...).Transactions())
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.Execute(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return content.Leagues, nil
+}
+
 // POST
 // Using POST, players can be added and/or dropped from a team, or trades can be
 // proposed. The URI for POSTing to transactions collection is:
@@ -3925,9 +5254,114 @@ count	Any integer greater than 0	/transactions;count=5
 // disallow, or vote against the trade (depending on which role you have in the
 // league). You may also cancel the trade.
 
+// IsWaiverClaim reports whether t is a waiver claim, which Yahoo! creates
+// instead of adding a player straight away when the player is on waivers.
+func (t *TransactionResource) IsWaiverClaim() bool {
+	if t.Type == string(WaiverTransaction) {
+		return true
+	}
+	k, err := ParseTransactionKey(t.TransactionKey)
+	return err == nil && k.Kind() == WaiverClaim
+}
 
+// IsSuccessful reports whether t has been carried out.
+func (t *TransactionResource) IsSuccessful() bool {
+	return t.Status == "successful"
+}
 
+// transactionPost is the document POSTed to a league's transactions
+// collection to add or drop players.
+type transactionPost struct {
+	XMLName xml.Name                `xml:"fantasy_content"`
+	Type    TransactionType         `xml:"transaction>type"`
+	Player  *transactionPostPlayer  `xml:"transaction>player,omitempty"`
+	Players *transactionPostPlayers `xml:"transaction>players,omitempty"`
+}
 
+type transactionPostPlayers struct {
+	Player []transactionPostPlayer `xml:"player"`
+}
+
+type transactionPostPlayer struct {
+	PlayerKey          string          `xml:"player_key"`
+	Type               TransactionType `xml:"transaction_data>type"`
+	SourceTeamKey      string          `xml:"transaction_data>source_team_key,omitempty"`
+	DestinationTeamKey string          `xml:"transaction_data>destination_team_key,omitempty"`
+}
+
+// AddPlayer adds the player identified by playerKey to the team identified
+// by teamKey. If the player is on waivers, the returned transaction is a
+// waiver claim to be processed later, as reported by IsWaiverClaim.
+func (c *Client) AddPlayer(ctx context.Context, teamKey TeamKey, playerKey PlayerKey) (*TransactionResource, error) {
+	add := transactionPostPlayer{PlayerKey: playerKey.String(), Type: AddTransaction, DestinationTeamKey: teamKey.String()}
+	return c.postTransaction(ctx, teamKey, transactionPost{Type: AddTransaction, Player: &add})
+}
+
+// DropPlayer drops the player identified by playerKey from the team
+// identified by teamKey.
+func (c *Client) DropPlayer(ctx context.Context, teamKey TeamKey, playerKey PlayerKey) (*TransactionResource, error) {
+	drop := transactionPostPlayer{PlayerKey: playerKey.String(), Type: DropTransaction, SourceTeamKey: teamKey.String()}
+	return c.postTransaction(ctx, teamKey, transactionPost{Type: DropTransaction, Player: &drop})
+}
+
+// AddDropPlayer replaces the player identified by dropKey with the one
+// identified by addKey on the team identified by teamKey, in a single
+// transaction. As with AddPlayer, the result may be a waiver claim.
+func (c *Client) AddDropPlayer(ctx context.Context, teamKey TeamKey, addKey, dropKey PlayerKey) (*TransactionResource, error) {
+	return c.postTransaction(ctx, teamKey, transactionPost{
+		Type: AddDropTransaction,
+		Players: &transactionPostPlayers{[]transactionPostPlayer{
+			{PlayerKey: addKey.String(), Type: AddTransaction, DestinationTeamKey: teamKey.String()},
+			{PlayerKey: dropKey.String(), Type: DropTransaction, SourceTeamKey: teamKey.String()},
+		}},
+	})
+}
+
+// postTransaction POSTs doc to the transactions collection of the league
+// of teamKey and returns the transaction Yahoo! created.
+func (c *Client) postTransaction(ctx context.Context, teamKey TeamKey, doc transactionPost) (*TransactionResource, error) {
+	var players []transactionPostPlayer
+	if doc.Player != nil {
+		players = append(players, *doc.Player)
+	}
+	if doc.Players != nil {
+		players = append(players, doc.Players.Player...)
+	}
+	if teamKey.String() == "" {
+		return nil, fmt.Errorf("yahooapi: a transaction requires a team key")
+	}
+	for _, p := range players {
+		if p.PlayerKey == "" {
+			return nil, fmt.Errorf("yahooapi: a transaction requires a player key for each player")
+		}
+	}
+	b, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	leagueKey := teamKey.League()
+	path, err := League(leagueKey.String()).Transactions().Path()
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.send(ctx, "POST", path, append([]byte(xml.Header), b...))
+	if err != nil {
+		return nil, err
+	}
+	// The league's rosters and transactions have changed, or will once a
+	// waiver claim is processed.
+	c.InvalidateLeague(leagueKey)
+
+	var content FantasyContent
+	if err := unmarshal(body, &content); err != nil {
+		return nil, err
+	}
+	if content.Transaction == nil {
+		return nil, ErrNotReturned
+	}
+	return content.Transaction, nil
+}
 
 // User resource
 // With the User API, you can retrieve fantasy information for a particular
@@ -3938,9 +5372,9 @@ count	Any integer greater than 0	/transactions;count=5
 // use_login flag, instead of trying to request a User resource directly from
 // the URI.
 type UserResource struct {
-	XMLName   xml.Name       `xml:"user",json:"-"`
-	UserGuids []string       `xml:"guid",json:",omitempty"`
-	Games     []GameResource `xml:"games>game",json:",omitempty"`
+	XMLName   xml.Name       `xml:"user" json:"-"`
+	UserGuids []string       `xml:"guid" json:"guid,omitempty"`
+	Games     []GameResource `xml:"games>game" json:"games,omitempty"`
 }
 
 // Users collection
@@ -3956,8 +5390,8 @@ type UserResource struct {
 //       </users>
 //     </fantasy_content>
 type UserCollection struct {
-	XMLName xml.Name       `xml:"fantasy_content",json:"-"`
-	Users   []UserResource `xml:"users>user",json:",omitempty"`
+	XMLName xml.Name       `xml:"fantasy_content" json:"-"`
+	Users   []UserResource `xml:"users>user" json:"users,omitempty"`
 }
 
 // GetUserResource
@@ -3977,83 +5411,56 @@ type UserCollection struct {
 //              accepts flags is_available to only return available games.
 // URI:         /fantasy/v2/;use_login=1/games
 // Sample:      http://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games
-func (y *YahooConfig) GetUserCollectionGames(r *http.Request) *UserCollection {
-	session, err := y.SessionStore.Get(r, "session-name")
+//
+// UserGames fetches the games in which the logged in user has played,
+// restricted by filter, which may be nil.
+func (c *Client) UserGames(ctx context.Context, filter *GamesFilter) (*UserCollection, error) {
+	q, err := filter.apply(Users().UseLogin().Games())
 	if err != nil {
-		log.Println(err.Error(), 500)
-		return nil
+		return nil, err
 	}
-
-	tok, ok := session.Values["token"].(*oauth2.Token)
-	if !ok {
-		log.Println("error deserializing token from session")
-		return nil
+	var userCollection UserCollection
+	if err := c.Get(ctx, q, &userCollection); err != nil {
+		return nil, err
 	}
-	client := y.conf.Client(oauth2.NoContext, tok)
 
-	req, err := http.NewRequest("GET", "https://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games", nil)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return &userCollection, nil
+}
 
-	res, err := client.Do(req)
-	if err != nil {
-		log.Fatal(err)
-	}
-	body, err := ioutil.ReadAll(res.Body)
+func (y *YahooConfig) GetUserCollectionGames(r *http.Request) (*UserCollection, error) {
+	client, err := y.Client(r)
 	if err != nil {
-		log.Fatal(err)
-	}
-	defer res.Body.Close()
-	var userCollection UserCollection
-	if xml.Unmarshal(body, &userCollection); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	return &userCollection
+	return client.UserGames(oauth2.NoContext, nil)
 }
 
 // Name:        /
 // Description: Fetch leagues that the user belongs to in one or more games. The leagues will be scoped to the user. This will throw an error if any of the specified games do not support league sub-resources.
 // URI:         /fantasy/v2/;use_login=1/games;game_keys=,{game_key2}/leagues
 // Sample:      http://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games;game_keys=223/leagues
-func (y *YahooConfig) GetUserCollectionLeagues(r *http.Request) *UserCollection {
-	session, err := y.SessionStore.Get(r, "session-name")
-	if err != nil {
-		log.Println(err.Error(), 500)
-		return nil
-	}
-
-	tok, ok := session.Values["token"].(*oauth2.Token)
-	if !ok {
-		log.Println("error deserializing token from session")
-		return nil
+//
+// UserLeagues fetches the leagues the logged in user belongs to in the games
+// identified by gameKeys.
+func (c *Client) UserLeagues(ctx context.Context, gameKeys ...GameKey) (*UserCollection, error) {
+	var userCollection UserCollection
+	if err := c.Get(ctx, Users().UseLogin().Games().GameKeys(gameKeyStrings(gameKeys)...).Leagues(), &userCollection); err != nil {
+		return nil, err
 	}
-	client := y.conf.Client(oauth2.NoContext, tok)
 
-	vars := mux.Vars(r)
-	game_keys := vars["game_keys"]
-	url := fmt.Sprintf("https://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games;game_keys=%s/leagues", game_keys)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return &userCollection, nil
+}
 
-	res, err := client.Do(req)
+func (y *YahooConfig) GetUserCollectionLeagues(r *http.Request) (*UserCollection, error) {
+	gameKeys, err := parseGameKeys(mux.Vars(r)["game_keys"])
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	body, err := ioutil.ReadAll(res.Body)
+	client, err := y.Client(r)
 	if err != nil {
-		log.Fatal(err)
-	}
-	defer res.Body.Close()
-	var userCollection UserCollection
-	if xml.Unmarshal(body, &userCollection); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	return &userCollection
+	return client.UserLeagues(oauth2.NoContext, gameKeys...)
 }
 
 // Name:
@@ -4062,43 +5469,28 @@ func (y *YahooConfig) GetUserCollectionLeagues(r *http.Request) *UserCollection
 //              the specified games do not support team sub-resources.
 // URI:         /fantasy/v2/;use_login=1/games;game_keys=,{game_key2}/teams
 // Sample:      http://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games;game_keys=223/teams
-func (y *YahooConfig) GetUserCollectionTeams(r *http.Request) *UserCollection {
-	session, err := y.SessionStore.Get(r, "session-name")
-	if err != nil {
-		log.Println(err.Error(), 500)
-		return nil
-	}
-
-	tok, ok := session.Values["token"].(*oauth2.Token)
-	if !ok {
-		log.Println("error deserializing token from session")
-		return nil
+//
+// UserTeams fetches the teams owned by the logged in user in the games
+// identified by gameKeys.
+func (c *Client) UserTeams(ctx context.Context, gameKeys ...GameKey) (*UserCollection, error) {
+	var userCollection UserCollection
+	if err := c.Get(ctx, Users().UseLogin().Games().GameKeys(gameKeyStrings(gameKeys)...).Teams(), &userCollection); err != nil {
+		return nil, err
 	}
-	client := y.conf.Client(oauth2.NoContext, tok)
 
-	vars := mux.Vars(r)
-	game_keys := vars["game_keys"]
-	url := fmt.Sprintf("https://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games;game_keys=%s/teams", game_keys)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return &userCollection, nil
+}
 
-	res, err := client.Do(req)
+func (y *YahooConfig) GetUserCollectionTeams(r *http.Request) (*UserCollection, error) {
+	gameKeys, err := parseGameKeys(mux.Vars(r)["game_keys"])
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	body, err := ioutil.ReadAll(res.Body)
+	client, err := y.Client(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer res.Body.Close()
-	var userCollection UserCollection
-	if xml.Unmarshal(body, &userCollection); err != nil {
-		log.Fatal(err)
-	}
-
-	return &userCollection
+	return client.UserTeams(oauth2.NoContext, gameKeys...)
 }
 
 // Any sub-resource valid for a user is a valid sub-resource under the users collection.
@@ -4107,41 +5499,26 @@ func (y *YahooConfig) GetUserCollectionTeams(r *http.Request) *UserCollection {
 // Multiple sub-resources can be extracted from users in the same URI using a format like:
 //     /users;use_login=1;out={sub_resource_1},{sub_resource_2}
 //     /users;field={field_name1},{field_name2}
-func (y *YahooConfig) GetUserCollectionAll(r *http.Request) *UserCollection {
-	session, err := y.SessionStore.Get(r, "session-name")
-	if err != nil {
-		log.Println(err.Error(), 500)
-		return nil
-	}
-
-	tok, ok := session.Values["token"].(*oauth2.Token)
-	if !ok {
-		log.Println("error deserializing token from session")
-		return nil
+//
+// UserGamesDetail fetches the games identified by gameKeys along with the
+// logged in user's leagues and teams in each of them.
+func (c *Client) UserGamesDetail(ctx context.Context, gameKeys ...GameKey) (*UserCollection, error) {
+	var userCollection UserCollection
+	if err := c.Get(ctx, Users().UseLogin().Games().GameKeys(gameKeyStrings(gameKeys)...).Out("teams", "leagues"), &userCollection); err != nil {
+		return nil, err
 	}
-	client := y.conf.Client(oauth2.NoContext, tok)
 
-	vars := mux.Vars(r)
-	game_keys := vars["game_keys"]
-	url := fmt.Sprintf("https://fantasysports.yahooapis.com/fantasy/v2/users;use_login=1/games;game_keys=%s;out=teams,leagues", game_keys)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return &userCollection, nil
+}
 
-	res, err := client.Do(req)
+func (y *YahooConfig) GetUserCollectionAll(r *http.Request) (*UserCollection, error) {
+	gameKeys, err := parseGameKeys(mux.Vars(r)["game_keys"])
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	body, err := ioutil.ReadAll(res.Body)
+	client, err := y.Client(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer res.Body.Close()
-	var userCollection UserCollection
-	if xml.Unmarshal(body, &userCollection); err != nil {
-		log.Fatal(err)
-	}
-
-	return &userCollection
+	return client.UserGamesDetail(oauth2.NoContext, gameKeys...)
 }
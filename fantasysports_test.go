@@ -0,0 +1,586 @@
+package yahooapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestGamesFilter(t *testing.T) {
+	tests := []struct {
+		filter *GamesFilter
+		path   string
+		err    string
+	}{
+		{nil, "games", ""},
+		{&GamesFilter{}, "games", ""},
+		{&GamesFilter{IsAvailable: true, GameCodes: []string{"nfl", "mlb"}}, "games;is_available=1;game_codes=nfl,mlb", ""},
+		{&GamesFilter{GameTypes: []GameType{FullGame, PickemTeamListGame}, Seasons: []int{2010, 2011}}, "games;game_types=full,pickem-team-list;seasons=2010,2011", ""},
+		{&GamesFilter{GameKeys: []GameKey{mustGameKey(t, "223"), mustGameKey(t, "pnfl")}}, "games;game_keys=223,pnfl", ""},
+		{&GamesFilter{GameTypes: []GameType{"fantasy"}}, "", `yahooapi: invalid game type "fantasy"`},
+		{&GamesFilter{GameCodes: []string{"nfl", "NBA"}}, "", `yahooapi: invalid game code "NBA"`},
+		{&GamesFilter{GameCodes: []string{"223"}}, "", `yahooapi: invalid game code "223"`},
+	}
+	for _, tt := range tests {
+		q, err := tt.filter.apply(Games())
+		checkFilter(t, tt.filter, q, err, tt.path, tt.err)
+	}
+}
+
+// checkFilter compares the query a filter was applied to, or the error of
+// applying it, with the path or error wanted.
+func checkFilter(t *testing.T, filter interface{}, q *Query, err error, path, wantErr string) {
+	var got string
+	if err == nil {
+		got, err = q.Path()
+	}
+	if wantErr != "" {
+		if err == nil || err.Error() != wantErr {
+			t.Errorf("%+v: got %q, %v, want error %s", filter, got, err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Errorf("%+v: %v", filter, err)
+		return
+	}
+	if got != path {
+		t.Errorf("%+v: got %s, want %s", filter, got, path)
+	}
+}
+
+func TestPlayerFilter(t *testing.T) {
+	league := League("223.l.431").Players()
+	date := Date{}
+	if err := date.UnmarshalText([]byte("2011-07-23")); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		q      *Query
+		filter *PlayerFilter
+		path   string
+		err    string
+	}{
+		{league, nil, "league/223.l.431/players", ""},
+		{league, &PlayerFilter{Position: "QB", Status: FreeAgents, Sort: SortByPoints, SortType: SortLastWeek, Count: 10},
+			"league/223.l.431/players;position=QB;status=FA;sort=PTS;sort_type=lastweek;count=10", ""},
+		{league, &PlayerFilter{Search: "de la rosa", Start: 25}, "league/223.l.431/players;search=de%20la%20rosa;start=25", ""},
+		{league, &PlayerFilter{Sort: "60", SortWeek: 10}, "league/223.l.431/players;sort=60;sort_type=week;sort_week=10", ""},
+		{league, &PlayerFilter{SortType: SortDate, SortDate: date}, "league/223.l.431/players;sort_type=date;sort_date=2011-07-23", ""},
+		{league, &PlayerFilter{SortSeason: 2010}, "league/223.l.431/players;sort_type=season;sort_season=2010", ""},
+		{Leagues().LeagueKeys("223.l.431", "223.l.432").Players(), &PlayerFilter{Status: TakenPlayers},
+			"leagues;league_keys=223.l.431,223.l.432/players;status=T", ""},
+
+		// Start and Count apply anywhere, the other filters only in a league.
+		{Players(), &PlayerFilter{Start: 25, Count: 25}, "players;start=25;count=25", ""},
+		{Team("223.l.431.t.1").Players(), &PlayerFilter{Count: 5}, "team/223.l.431.t.1/players;count=5", ""},
+		{Players(), &PlayerFilter{Position: "QB"}, "", "yahooapi: player filters other than start and count only apply to the players of a league"},
+		{Team("223.l.431.t.1").Players(), &PlayerFilter{Status: FreeAgents}, "", "yahooapi: player filters other than start and count only apply to the players of a league"},
+		{Game("nfl").Players(), &PlayerFilter{Search: "smith"}, "", "yahooapi: player filters other than start and count only apply to the players of a league"},
+
+		{league, &PlayerFilter{Status: "X"}, "", `yahooapi: invalid player status "X"`},
+		{league, &PlayerFilter{Sort: "points"}, "", `yahooapi: invalid player sort "points"`},
+		{league, &PlayerFilter{SortType: "day"}, "", `yahooapi: invalid player sort type "day"`},
+		{league, &PlayerFilter{SortType: SortSeason, SortWeek: 10}, "", "yahooapi: sort_week requires sort_type=week"},
+		{league, &PlayerFilter{SortType: SortLastMonth, SortDate: date}, "", "yahooapi: sort_date requires sort_type=date"},
+		{league, &PlayerFilter{SortSeason: 2010, SortWeek: 10}, "", "yahooapi: only one of sort_season, sort_date and sort_week may be set"},
+		{league, &PlayerFilter{SortWeek: -1}, "", "yahooapi: invalid sort week -1"},
+		{league, &PlayerFilter{Start: -1}, "", "yahooapi: invalid start -1"},
+		{league, &PlayerFilter{Count: -5}, "", "yahooapi: invalid count -5"},
+	}
+	for _, tt := range tests {
+		q, err := tt.filter.apply(tt.q)
+		checkFilter(t, tt.filter, q, err, tt.path, tt.err)
+	}
+}
+
+func TestTransactionsFilter(t *testing.T) {
+	league := League("257.l.193").Transactions()
+	team := mustGameKey(t, "257").League(193).Team(1)
+	tests := []struct {
+		filter *TransactionsFilter
+		path   string
+		err    string
+	}{
+		{nil, "league/257.l.193/transactions", ""},
+		{&TransactionsFilter{Types: []TransactionType{AddTransaction}}, "league/257.l.193/transactions;type=add", ""},
+		{&TransactionsFilter{Types: []TransactionType{AddTransaction, TradeTransaction}, Count: 5}, "league/257.l.193/transactions;types=add,trade;count=5", ""},
+		{&TransactionsFilter{TeamKey: team}, "league/257.l.193/transactions;team_key=257.l.193.t.1", ""},
+		{&TransactionsFilter{Types: []TransactionType{WaiverTransaction}, TeamKey: team}, "league/257.l.193/transactions;type=waiver;team_key=257.l.193.t.1", ""},
+		{&TransactionsFilter{Types: []TransactionType{PendingTradeTransaction}, TeamKey: team}, "league/257.l.193/transactions;type=pending_trade;team_key=257.l.193.t.1", ""},
+
+		{&TransactionsFilter{Types: []TransactionType{WaiverTransaction}}, "", "yahooapi: filtering transactions by waiver requires a team key"},
+		{&TransactionsFilter{Types: []TransactionType{AddTransaction, PendingTradeTransaction}}, "", "yahooapi: filtering transactions by pending_trade requires a team key"},
+		{&TransactionsFilter{Types: []TransactionType{AddDropTransaction}}, "", `yahooapi: invalid transaction type "add/drop"`},
+		{&TransactionsFilter{Types: []TransactionType{"keeper"}}, "", `yahooapi: invalid transaction type "keeper"`},
+		{&TransactionsFilter{Count: -1}, "", "yahooapi: invalid count -1"},
+	}
+	for _, tt := range tests {
+		q, err := tt.filter.apply(league)
+		checkFilter(t, tt.filter, q, err, tt.path, tt.err)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	sample, err := ioutil.ReadFile(filepath.Join("testdata", "transaction.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write(sample)
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+	key, err := ParseTransactionKey("257.l.193.tr.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := c.Transaction(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/transaction/257.l.193.tr.2/players"; path != want {
+		t.Errorf("got %s, want %s", path, want)
+	}
+	if tr.TransactionKey != "257.l.193.tr.2" || tr.Type != "add/drop" {
+		t.Errorf("got %s %s, want 257.l.193.tr.2 add/drop", tr.TransactionKey, tr.Type)
+	}
+	want := []struct {
+		playerKey, typ, source, destination string
+	}{
+		{"257.p.7847", "add", "freeagents", "257.l.193.t.1"},
+		{"257.p.6390", "drop", "257.l.193.t.1", "waivers"},
+	}
+	if len(tr.Players) != len(want) {
+		t.Fatalf("got %d players, want %d", len(tr.Players), len(want))
+	}
+	for i, w := range want {
+		p := tr.Players[i]
+		d := p.TransactionData
+		if d == nil {
+			t.Errorf("%s: no transaction data", p.PlayerKey)
+			continue
+		}
+		source, destination := d.SourceTeamKey, d.DestinationTeamKey
+		if source == "" {
+			source = d.SourceType
+		}
+		if destination == "" {
+			destination = d.DestinationType
+		}
+		if p.PlayerKey != w.playerKey || d.Type != w.typ || source != w.source || destination != w.destination {
+			t.Errorf("player %d: got %s %s from %s to %s, want %s %s from %s to %s", i,
+				p.PlayerKey, d.Type, source, destination, w.playerKey, w.typ, w.source, w.destination)
+		}
+	}
+}
+
+func TestDraftResults(t *testing.T) {
+	league, err := ioutil.ReadFile(filepath.Join("testdata", "league_draftresults.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := `<fantasy_content><team><team_key>223.l.431.t.4</team_key><draft_results><draft_result><pick>1</pick><round>1</round><team_key>223.l.431.t.4</team_key><player_key>223.p.8261</player_key></draft_result></draft_results></team></fantasy_content>`
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if strings.HasPrefix(path, "/team/") {
+			w.Write([]byte(team))
+			return
+		}
+		w.Write(league)
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+	leagueKey := mustGameKey(t, "223").League(431)
+
+	// Players are joined with out=players in a single request rather than
+	// requested as a nested sub-resource.
+	tests := []struct {
+		withPlayers bool
+		path        string
+	}{
+		{false, "/league/223.l.431/draftresults"},
+		{true, "/league/223.l.431/draftresults;out=players"},
+	}
+	for _, tt := range tests {
+		picks, err := c.LeagueDraftResults(context.Background(), leagueKey, tt.withPlayers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != tt.path {
+			t.Errorf("withPlayers %v: got %s, want %s", tt.withPlayers, path, tt.path)
+		}
+		if len(picks) != 2 || picks[0].PlayerKey != "223.p.8261" || picks[0].Player == nil || picks[0].Player.Name.Full != "Adrian Peterson" {
+			t.Errorf("withPlayers %v: got %+v", tt.withPlayers, picks)
+		}
+	}
+
+	picks, err := c.TeamDraftResults(context.Background(), leagueKey.Team(4), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/team/223.l.431.t.4/draftresults;out=players"; path != want {
+		t.Errorf("got %s, want %s", path, want)
+	}
+	if len(picks) != 1 || picks[0].Pick != 1 || picks[0].TeamKey != "223.l.431.t.4" {
+		t.Errorf("got %+v", picks)
+	}
+}
+
+func TestTeamOptions(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`<fantasy_content><team><team_key>223.l.431.t.1</team_key></team></fantasy_content>`))
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+	team := mustGameKey(t, "223").League(431).Team(1)
+
+	if _, err := c.Team(context.Background(), team, WithTeamWeekStats(2), WithTeamStandings()); err != nil {
+		t.Fatal(err)
+	}
+	if want := "/team/223.l.431.t.1;type=week;week=2;out=stats,standings"; len(paths) != 1 || paths[0] != want {
+		t.Errorf("got paths %v, want %s", paths, want)
+	}
+
+	// Season and weekly stats are the same sub-resource with different
+	// parameters, so they can't be fetched together.
+	paths = nil
+	_, err := c.Team(context.Background(), team, WithTeamStats(), WithTeamWeekStats(2))
+	if err == nil || !strings.Contains(err.Error(), "stats requested with conflicting parameters") {
+		t.Errorf("got %v, want a conflicting parameters error", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("got paths %v, want no requests", paths)
+	}
+}
+
+// lineupServer responds to PUTs to a roster with the status put, recording
+// their bodies, and to GETs with the status get and, if that is 200 OK,
+// testdata/team_roster.xml.
+type lineupServer struct {
+	*httptest.Server
+	put, get    int
+	contentType string
+	bodies      []string
+	gets        int
+}
+
+func newLineupServer(t *testing.T) *lineupServer {
+	roster, err := ioutil.ReadFile(filepath.Join("testdata", "team_roster.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &lineupServer{put: http.StatusOK, get: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			b, _ := ioutil.ReadAll(r.Body)
+			s.contentType = r.Header.Get("Content-Type")
+			s.bodies = append(s.bodies, string(b))
+			w.WriteHeader(s.put)
+			if s.put != http.StatusOK {
+				w.Write([]byte(`<error xmlns="http://www.yahooapis.com/v1/base.rng"><description>Player 253.p.7569 is not eligible at position SS</description><detail/></error>`))
+			}
+		case "GET":
+			s.gets++
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(s.get)
+			if s.get == http.StatusOK {
+				w.Write(roster)
+			}
+		}
+	}))
+	return s
+}
+
+func TestSetLineup(t *testing.T) {
+	var date Date
+	if err := date.UnmarshalText([]byte("2011-07-22")); err != nil {
+		t.Fatal(err)
+	}
+	teamKey, err := ParseTeamKey("253.l.102614.t.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions := []PlayerPosition{
+		{mustPlayerKey(t, "253.p.7569"), "C"},
+		{mustPlayerKey(t, "253.p.7054"), "BN"},
+	}
+	tests := []struct {
+		coverage RosterAt
+		body     string
+	}{
+		{RosterAt{Week: 13}, `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<fantasy_content><roster><coverage_type>week</coverage_type><week>13</week><players>` +
+			`<player><player_key>253.p.7569</player_key><position>C</position></player>` +
+			`<player><player_key>253.p.7054</player_key><position>BN</position></player>` +
+			`</players></roster></fantasy_content>`},
+		{RosterAt{Date: date}, `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<fantasy_content><roster><coverage_type>date</coverage_type><date>2011-07-22</date><players>` +
+			`<player><player_key>253.p.7569</player_key><position>C</position></player>` +
+			`<player><player_key>253.p.7054</player_key><position>BN</position></player>` +
+			`</players></roster></fantasy_content>`},
+	}
+	for _, tt := range tests {
+		srv := newLineupServer(t)
+		c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+		roster, err := c.SetLineup(context.Background(), teamKey, tt.coverage, positions)
+		srv.Close()
+		if err != nil {
+			t.Errorf("%+v: %v", tt.coverage, err)
+			continue
+		}
+		if len(srv.bodies) != 1 || srv.bodies[0] != tt.body {
+			t.Errorf("%+v: got PUT bodies\n%q\nwant\n%q", tt.coverage, srv.bodies, tt.body)
+		}
+		if srv.contentType != "application/xml" {
+			t.Errorf("%+v: got Content-Type %q, want application/xml", tt.coverage, srv.contentType)
+		}
+		if roster == nil || roster.CoverageType != "date" || len(roster.Players) == 0 {
+			t.Errorf("%+v: got roster %+v", tt.coverage, roster)
+		}
+	}
+}
+
+func TestSetLineupErrors(t *testing.T) {
+	teamKey, err := ParseTeamKey("253.l.102614.t.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions := []PlayerPosition{{mustPlayerKey(t, "253.p.7569"), "SS"}}
+
+	// Yahoo! rejects the lineup: the roster is not refetched and its
+	// explanation reaches the caller.
+	srv := newLineupServer(t)
+	srv.put = http.StatusBadRequest
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+	_, err = c.SetLineup(context.Background(), teamKey, RosterAt{Week: 13}, positions)
+	srv.Close()
+	e, ok := err.(*APIError)
+	if !ok || e.StatusCode != http.StatusBadRequest || e.Description != "Player 253.p.7569 is not eligible at position SS" {
+		t.Errorf("rejected lineup: got %v, want the APIError of the PUT", err)
+	}
+	if srv.gets != 0 {
+		t.Errorf("rejected lineup: got %d GETs, want 0", srv.gets)
+	}
+
+	// The lineup is saved but the roster can't be fetched afterwards.
+	srv = newLineupServer(t)
+	srv.get = http.StatusServiceUnavailable
+	c = &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+	_, err = c.SetLineup(context.Background(), teamKey, RosterAt{Week: 13}, positions)
+	srv.Close()
+	re, ok := err.(*RefetchError)
+	if !ok {
+		t.Fatalf("failed refetch: got %v, want a *RefetchError", err)
+	}
+	if e, ok := re.Err.(*APIError); !ok || e.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("failed refetch: got %v, want the APIError of the GET", re.Err)
+	}
+	if len(srv.bodies) != 1 {
+		t.Errorf("failed refetch: got %d PUTs, want 1", len(srv.bodies))
+	}
+
+	// Invalid arguments are rejected without a request.
+	for _, tt := range []struct {
+		coverage  RosterAt
+		positions []PlayerPosition
+	}{
+		{RosterAt{}, positions},
+		{RosterAt{Week: -1}, positions},
+		{RosterAt{Week: 13}, nil},
+		{RosterAt{Week: 13}, []PlayerPosition{{mustPlayerKey(t, "253.p.7569"), ""}}},
+		{RosterAt{Week: 13}, []PlayerPosition{{PlayerKey{}, "C"}}},
+	} {
+		if _, err := c.SetLineup(context.Background(), teamKey, tt.coverage, tt.positions); err == nil {
+			t.Errorf("%+v %+v: got no error", tt.coverage, tt.positions)
+		}
+	}
+}
+
+func TestSetLineupInvalidates(t *testing.T) {
+	srv := newLineupServer(t)
+	defer srv.Close()
+	cache := NewMemoryCache(0)
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient, Cache: cache}
+	stale := []string{
+		srv.URL + "/league/253.l.102614/scoreboard",
+		srv.URL + "/team/253.l.102614.t.10/roster;week=13",
+		srv.URL + "/team/253.l.102614.t.3/roster",
+	}
+	kept := srv.URL + "/league/253.l.999/scoreboard"
+	for _, uri := range append(stale, kept) {
+		cache.Set(cacheKey("", uri), []byte("<fantasy_content/>"), time.Hour)
+	}
+
+	teamKey, err := ParseTeamKey("253.l.102614.t.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SetLineup(context.Background(), teamKey, RosterAt{Week: 13}, []PlayerPosition{{mustPlayerKey(t, "253.p.7569"), "C"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, uri := range stale {
+		if body, ok := cache.Get(cacheKey("", uri)); ok && string(body) == "<fantasy_content/>" {
+			t.Errorf("%s: still cached", uri)
+		}
+	}
+	if _, ok := cache.Get(cacheKey("", kept)); !ok {
+		t.Errorf("%s: removed", kept)
+	}
+}
+
+func mustPlayerKey(t *testing.T, s string) PlayerKey {
+	k, err := ParsePlayerKey(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestPostTransaction(t *testing.T) {
+	const header = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+	const successful = `<fantasy_content><transaction><transaction_key>257.l.193.tr.26</transaction_key><transaction_id>26</transaction_id><type>%s</type><status>successful</status></transaction></fantasy_content>`
+	const waiver = `<fantasy_content><transaction><transaction_key>257.l.193.w.c.2_6390</transaction_key><type>waiver</type><status>pending</status><waiver_player_key>257.p.7847</waiver_player_key><waiver_team_key>257.l.193.t.1</waiver_team_key><waiver_date>2011-11-02</waiver_date></transaction></fantasy_content>`
+
+	teamKey, err := ParseTeamKey("257.l.193.t.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	add, drop := mustPlayerKey(t, "257.p.7847"), mustPlayerKey(t, "257.p.6390")
+	tests := []struct {
+		name     string
+		post     func(*Client) (*TransactionResource, error)
+		body     string
+		response string
+		waiver   bool
+	}{
+		{
+			"add",
+			func(c *Client) (*TransactionResource, error) { return c.AddPlayer(context.Background(), teamKey, add) },
+			header + `<fantasy_content><transaction><type>add</type>` +
+				`<player><player_key>257.p.7847</player_key><transaction_data><type>add</type><destination_team_key>257.l.193.t.1</destination_team_key></transaction_data></player>` +
+				`</transaction></fantasy_content>`,
+			fmt.Sprintf(successful, "add"), false,
+		},
+		{
+			"add from waivers",
+			func(c *Client) (*TransactionResource, error) { return c.AddPlayer(context.Background(), teamKey, add) },
+			header + `<fantasy_content><transaction><type>add</type>` +
+				`<player><player_key>257.p.7847</player_key><transaction_data><type>add</type><destination_team_key>257.l.193.t.1</destination_team_key></transaction_data></player>` +
+				`</transaction></fantasy_content>`,
+			waiver, true,
+		},
+		{
+			"drop",
+			func(c *Client) (*TransactionResource, error) {
+				return c.DropPlayer(context.Background(), teamKey, drop)
+			},
+			header + `<fantasy_content><transaction><type>drop</type>` +
+				`<player><player_key>257.p.6390</player_key><transaction_data><type>drop</type><source_team_key>257.l.193.t.1</source_team_key></transaction_data></player>` +
+				`</transaction></fantasy_content>`,
+			fmt.Sprintf(successful, "drop"), false,
+		},
+		{
+			"add/drop",
+			func(c *Client) (*TransactionResource, error) {
+				return c.AddDropPlayer(context.Background(), teamKey, add, drop)
+			},
+			header + `<fantasy_content><transaction><type>add/drop</type><players>` +
+				`<player><player_key>257.p.7847</player_key><transaction_data><type>add</type><destination_team_key>257.l.193.t.1</destination_team_key></transaction_data></player>` +
+				`<player><player_key>257.p.6390</player_key><transaction_data><type>drop</type><source_team_key>257.l.193.t.1</source_team_key></transaction_data></player>` +
+				`</players></transaction></fantasy_content>`,
+			fmt.Sprintf(successful, "add/drop"), false,
+		},
+	}
+	for _, tt := range tests {
+		var method, path, contentType, body string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			method, path, contentType, body = r.Method, r.URL.Path, r.Header.Get("Content-Type"), string(b)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, tt.response)
+		}))
+		cache := NewMemoryCache(0)
+		stale := cacheKey("", srv.URL+"/league/257.l.193/transactions")
+		cache.Set(stale, []byte("<fantasy_content/>"), time.Hour)
+		c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient, Cache: cache}
+		tr, err := tt.post(c)
+		srv.Close()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if method != "POST" || path != "/league/257.l.193/transactions" || contentType != "application/xml" {
+			t.Errorf("%s: got %s %s (%s), want POST /league/257.l.193/transactions (application/xml)", tt.name, method, path, contentType)
+		}
+		if body != tt.body {
+			t.Errorf("%s: got body\n%s\nwant\n%s", tt.name, body, tt.body)
+		}
+		if tr.IsWaiverClaim() != tt.waiver || tr.IsSuccessful() == tt.waiver {
+			t.Errorf("%s: got IsWaiverClaim %v, IsSuccessful %v, want %v, %v", tt.name, tr.IsWaiverClaim(), tr.IsSuccessful(), tt.waiver, !tt.waiver)
+		}
+		if _, ok := cache.Get(stale); ok {
+			t.Errorf("%s: league transactions still cached", tt.name)
+		}
+	}
+}
+
+func TestPostTransactionErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<error xmlns="http://www.yahooapis.com/v1/base.rng"><description>You cannot add a player you already have on your roster.</description><detail/></error>`)
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+	teamKey, err := ParseTeamKey("257.l.193.t.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.AddPlayer(context.Background(), teamKey, mustPlayerKey(t, "257.p.7847"))
+	if e, ok := err.(*APIError); !ok || e.Description != "You cannot add a player you already have on your roster." {
+		t.Errorf("got %v, want the APIError of the POST", err)
+	}
+	if _, err := c.AddPlayer(context.Background(), TeamKey{}, mustPlayerKey(t, "257.p.7847")); err == nil {
+		t.Errorf("zero team key: got no error")
+	}
+	if _, err := c.DropPlayer(context.Background(), teamKey, PlayerKey{}); err == nil {
+		t.Errorf("zero player key: got no error")
+	}
+}
+
+func TestTransactionClassification(t *testing.T) {
+	tests := []struct {
+		tr                 TransactionResource
+		waiver, successful bool
+	}{
+		{TransactionResource{TransactionKey: "257.l.193.w.c.2_6390", Type: "waiver", Status: "pending"}, true, false},
+		{TransactionResource{TransactionKey: "257.l.193.w.c.2_6390", Type: "add/drop", Status: "pending"}, true, false},
+		{TransactionResource{TransactionKey: "257.l.193.tr.26", Type: "add", Status: "successful"}, false, true},
+		{TransactionResource{TransactionKey: "257.l.193.tr.2", Type: "add/drop", Status: "successful"}, false, true},
+		{TransactionResource{TransactionKey: "257.l.193.pt.1", Type: "pending_trade", Status: "proposed"}, false, false},
+		{TransactionResource{}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.tr.IsWaiverClaim(); got != tt.waiver {
+			t.Errorf("%s %s: got IsWaiverClaim %v", tt.tr.TransactionKey, tt.tr.Type, got)
+		}
+		if got := tt.tr.IsSuccessful(); got != tt.successful {
+			t.Errorf("%s %s: got IsSuccessful %v", tt.tr.TransactionKey, tt.tr.Type, got)
+		}
+	}
+}
@@ -1,75 +1,95 @@
 package yahooapi
 
 import (
-	// "io"
-	"net/http"
-	// "fmt"
 	"encoding/json"
-	"log"
+	"net/http"
 )
 
-func (y *YahooConfig) UserCollectionGamesHandler(w http.ResponseWriter, r *http.Request) {
-	user := y.GetUserCollectionGames(r)
-	// io.WriteString(w, user.Body)
-	// io.WriteString(w, fmt.Sprintf("%v", user))
-	b, err := json.MarshalIndent(user, "", "  ")
+// writeError reports err to the caller, passing through the status of
+// errors returned by the Fantasy Sports API.
+func writeError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case *KeyError:
+		http.Error(w, e.Error(), http.StatusBadRequest)
+		return
+	case *APIError:
+		if e.StatusCode >= 400 && e.StatusCode < 600 {
+			http.Error(w, e.Error(), e.StatusCode)
+			return
+		}
+	}
+	http.Error(w, err.Error(), 500)
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		log.Fatal(err)
+		http.Error(w, err.Error(), 500)
+		return
 	}
 	w.Write(b)
 }
 
+func (y *YahooConfig) UserCollectionGamesHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := y.GetUserCollectionGames(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, user)
+}
+
 func (y *YahooConfig) UserCollectionLeaguesHandler(w http.ResponseWriter, r *http.Request) {
-	user := y.GetUserCollectionLeagues(r)
-	// io.WriteString(w, user.Body)
-	// io.WriteString(w, fmt.Sprintf("%v", user))
-	b, err := json.MarshalIndent(user, "", "  ")
+	user, err := y.GetUserCollectionLeagues(r)
 	if err != nil {
-	  log.Fatal(err)
+		writeError(w, err)
+		return
 	}
-	w.Write(b)
+	writeJSON(w, user)
 }
 
 func (y *YahooConfig) UserCollectionTeamsHandler(w http.ResponseWriter, r *http.Request) {
-	user := y.GetUserCollectionTeams(r)
-	// io.WriteString(w, user.Body)
-	// io.WriteString(w, fmt.Sprintf("%v", user))
-	b, err := json.MarshalIndent(user, "", "  ")
+	user, err := y.GetUserCollectionTeams(r)
 	if err != nil {
-	  log.Fatal(err)
+		writeError(w, err)
+		return
 	}
-	w.Write(b)
+	writeJSON(w, user)
 }
 
 func (y *YahooConfig) UserCollectionAllHandler(w http.ResponseWriter, r *http.Request) {
-	user := y.GetUserCollectionAll(r)
-	// io.WriteString(w, user.Body)
-	// io.WriteString(w, fmt.Sprintf("%v", user))
-	b, err := json.MarshalIndent(user, "", "  ")
+	user, err := y.GetUserCollectionAll(r)
 	if err != nil {
-	  log.Fatal(err)
+		writeError(w, err)
+		return
 	}
-	w.Write(b)
+	writeJSON(w, user)
 }
 
 func (y *YahooConfig) LeagueScoreboardHandler(w http.ResponseWriter, r *http.Request) {
-	scoreboard := y.GetLeagueScoreboard(r)
-	// io.WriteString(w, user.Body)
-	// io.WriteString(w, fmt.Sprintf("%v", user))
-	b, err := json.MarshalIndent(scoreboard, "", "  ")
+	scoreboard, err := y.GetLeagueScoreboard(r)
 	if err != nil {
-	  log.Fatal(err)
+		writeError(w, err)
+		return
 	}
-	w.Write(b)
+	writeJSON(w, scoreboard)
 }
 
 func (y *YahooConfig) LeagueStandingsHandler(w http.ResponseWriter, r *http.Request) {
-	standings := y.GetLeagueStandings(r)
-	// io.WriteString(w, user.Body)
-	// io.WriteString(w, fmt.Sprintf("%v", user))
-	b, err := json.MarshalIndent(standings, "", "  ")
+	standings, err := y.GetLeagueStandings(r)
 	if err != nil {
-	  log.Fatal(err)
+		writeError(w, err)
+		return
 	}
-	w.Write(b)
+	writeJSON(w, standings)
+}
+
+func (y *YahooConfig) TransactionCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	transactions, err := y.GetTransactionCollection(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, transactions)
 }
@@ -0,0 +1,94 @@
+package yahooapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// TestJSONGolden locks in the JSON representation of the resource types by
+// comparing the marshaled form of each sample with testdata/golden.
+func TestJSONGolden(t *testing.T) {
+	for _, name := range jsonParitySamples {
+		got, err := json.MarshalIndent(decodeSample(t, name+".xml"), "", "  ")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got = append(got, '\n')
+
+		golden := filepath.Join("testdata", "golden", name+".json")
+		if *update {
+			if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: got\n%s\nwant\n%s", name, got, want)
+		}
+	}
+}
+
+// alwaysPresent are the number and bool fields that are marshaled even
+// when zero, as documented in the package comment.
+var alwaysPresent = map[string]bool{
+	"StatCategoryResource.StatID":          true,
+	"StatCategoryResource.SortOrder":       true,
+	"StatModifierResource.StatID":          true,
+	"StatModifierResource.Value":           true,
+	"StatResource.StatID":                  true,
+	"OutcomeTotalsResource.Wins":           true,
+	"OutcomeTotalsResource.Losses":         true,
+	"OutcomeTotalsResource.Ties":           true,
+	"StartingStatusResource.IsStarting":    true,
+	"PercentOwnedResource.Value":           true,
+	"PercentOwnedResource.Delta":           true,
+	"DraftAnalysisResource.AveragePick":    true,
+	"DraftAnalysisResource.AverageRound":   true,
+	"DraftAnalysisResource.PercentDrafted": true,
+}
+
+// TestJSONOmitEmpty checks that every number and bool field of the
+// resource types is tagged omitempty unless it is one of alwaysPresent.
+func TestJSONOmitEmpty(t *testing.T) {
+	seen := make(map[reflect.Type]bool)
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || seen[typ] || typ.PkgPath() != reflect.TypeOf(FantasyContent{}).PkgPath() {
+			return
+		}
+		seen[typ] = true
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			walk(f.Type)
+			tag := strings.Split(f.Tag.Get("json"), ",")
+			if tag[0] == "-" {
+				continue
+			}
+			switch f.Type.Kind() {
+			case reflect.Bool, reflect.Int, reflect.Float64:
+			default:
+				continue
+			}
+			omit := len(tag) > 1 && tag[1] == "omitempty"
+			if omit == alwaysPresent[typ.Name()+"."+f.Name] {
+				t.Errorf("%s.%s: json tag %q, want omitempty %v", typ.Name(), f.Name, f.Tag.Get("json"), !omit)
+			}
+		}
+	}
+	walk(reflect.TypeOf(FantasyContent{}))
+}
@@ -0,0 +1,99 @@
+package yahooapi
+
+import (
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// PlayersPageSize is the number of players a PlayerIterator requests at a
+// time, which is the most Yahoo! returns in one response.
+const PlayersPageSize = 25
+
+// PlayerIterator walks a players collection page by page using its start
+// and count parameters. It is used like a bufio.Scanner:
+//
+//   it := client.IteratePlayers(ctx, League("223.l.431").Players().Param("status", "FA"))
+//   for it.Next() {
+//     p := it.Player()
+//     ...
+//   }
+//   if err := it.Err(); err != nil {
+//     ...
+//   }
+type PlayerIterator struct {
+	c     *Client
+	ctx   context.Context
+	q     *Query
+	start int
+
+	page   []PlayerResource
+	next   int
+	last   bool
+	player *PlayerResource
+	err    error
+}
+
+// IteratePlayers returns an iterator over the players collection at the
+// end of q, which is typically the players of a league or game with
+// filters applied as parameters. Any start or count parameters of q are
+// replaced. Canceling ctx stops the iteration.
+func (c *Client) IteratePlayers(ctx context.Context, q *Query) *PlayerIterator {
+	return &PlayerIterator{c: c, ctx: ctx, q: q}
+}
+
+// Next advances to the next player, fetching the next page if needed. It
+// returns false at the end of the collection or on error.
+func (it *PlayerIterator) Next() bool {
+	it.player = nil
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	if it.next == len(it.page) {
+		if it.last {
+			return false
+		}
+		if it.err = it.fetch(); it.err != nil || len(it.page) == 0 {
+			return false
+		}
+	}
+	it.player = &it.page[it.next]
+	it.next++
+	return true
+}
+
+// Player returns the current player, or nil if Next has not returned true.
+func (it *PlayerIterator) Player() *PlayerResource {
+	return it.player
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PlayerIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the page beginning at it.start.
+func (it *PlayerIterator) fetch() error {
+	q := it.q.Param("start", strconv.Itoa(it.start)).Param("count", strconv.Itoa(PlayersPageSize))
+	content, err := it.c.Execute(it.ctx, q)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case content.League != nil:
+		it.page = content.League.Players
+	case content.Game != nil:
+		it.page = content.Game.Players
+	default:
+		it.page = content.Players
+	}
+	it.next = 0
+	it.start += len(it.page)
+	it.last = len(it.page) < PlayersPageSize
+	return nil
+}
@@ -0,0 +1,145 @@
+package yahooapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+// playersServer serves pages of a league with total players, honoring the
+// start and count parameters of the players collection. The page starting
+// at failAt, if positive, is refused with a 500. It records the paths it
+// receives.
+func playersServer(total, failAt int) (*httptest.Server, *[]string) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		params := make(map[string]int)
+		for _, p := range strings.Split(r.URL.Path, ";")[1:] {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) == 2 {
+				params[kv[0]], _ = strconv.Atoi(kv[1])
+			}
+		}
+		start, count := params["start"], params["count"]
+		if failAt > 0 && start == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `<fantasy_content><league><league_key>223.l.431</league_key><players>`)
+		for i := start; i < start+count && i < total; i++ {
+			fmt.Fprintf(w, "<player><player_key>223.p.%d</player_key></player>", i)
+		}
+		fmt.Fprint(w, `</players></league></fantasy_content>`)
+	}))
+	return srv, &paths
+}
+
+func TestIteratePlayers(t *testing.T) {
+	tests := []struct {
+		total    int
+		requests int
+	}{
+		{0, 1},
+		{3, 1},
+		{PlayersPageSize + 3, 2},
+		// A full last page takes one more request, which returns nothing.
+		{PlayersPageSize, 2},
+		{2 * PlayersPageSize, 3},
+	}
+	for _, tt := range tests {
+		srv, paths := playersServer(tt.total, 0)
+		c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+		it := c.IteratePlayers(context.Background(), League("223.l.431").Players())
+		var n int
+		for it.Next() {
+			if want := fmt.Sprintf("223.p.%d", n); it.Player().PlayerKey != want {
+				t.Errorf("total %d: player %d is %s, want %s", tt.total, n, it.Player().PlayerKey, want)
+			}
+			n++
+		}
+		// Further calls neither fetch again nor return players.
+		if it.Next() || it.Player() != nil {
+			t.Errorf("total %d: Next returned a player after the end", tt.total)
+		}
+		srv.Close()
+		if err := it.Err(); err != nil {
+			t.Errorf("total %d: %v", tt.total, err)
+		}
+		if n != tt.total || len(*paths) != tt.requests {
+			t.Errorf("total %d: got %d players in %d requests, want %d in %d", tt.total, n, len(*paths), tt.total, tt.requests)
+		}
+	}
+}
+
+func TestIteratePlayersParams(t *testing.T) {
+	srv, paths := playersServer(PlayersPageSize+1, 0)
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+
+	// The caller's start and count are replaced by the iterator's.
+	q := League("223.l.431").Players().Param("status", "FA").Param("start", "100").Param("count", "5")
+	it := c.IteratePlayers(context.Background(), q)
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"/league/223.l.431/players;status=FA;start=0;count=25",
+		"/league/223.l.431/players;status=FA;start=25;count=25",
+	}
+	if fmt.Sprint(*paths) != fmt.Sprint(want) {
+		t.Errorf("got paths %v, want %v", *paths, want)
+	}
+}
+
+func TestIteratePlayersCanceled(t *testing.T) {
+	srv, paths := playersServer(2*PlayersPageSize, 0)
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.IteratePlayers(ctx, League("223.l.431").Players())
+	var n int
+	for it.Next() {
+		if n++; n == 3 {
+			cancel()
+		}
+	}
+	if n != 3 {
+		t.Errorf("got %d players, want 3", n)
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("got %v, want %v", it.Err(), context.Canceled)
+	}
+	if len(*paths) != 1 {
+		t.Errorf("got %d requests, want 1", len(*paths))
+	}
+}
+
+func TestIteratePlayersError(t *testing.T) {
+	srv, paths := playersServer(2*PlayersPageSize, PlayersPageSize)
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL + "/", client: http.DefaultClient}
+
+	it := c.IteratePlayers(context.Background(), League("223.l.431").Players())
+	var n int
+	for it.Next() {
+		n++
+	}
+	if n != PlayersPageSize {
+		t.Errorf("got %d players, want %d", n, PlayersPageSize)
+	}
+	if err, ok := it.Err().(*APIError); !ok || err.StatusCode != http.StatusInternalServerError {
+		t.Errorf("got %v, want an *APIError with status 500", it.Err())
+	}
+	if it.Next() || len(*paths) != 2 {
+		t.Errorf("Next continued after an error: %d requests", len(*paths))
+	}
+}
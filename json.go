@@ -0,0 +1,210 @@
+package yahooapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Format selects the representation a Client asks the Fantasy Sports API
+// to respond with. Both are decoded into the same types.
+type Format string
+
+const (
+	// XML is the API's default format.
+	XML Format = "xml"
+
+	// JSON asks for format=json responses.
+	JSON Format = "json"
+)
+
+// rootAttrs are the keys of the top level JSON object that correspond to
+// attributes, rather than child elements, in the XML representation.
+var rootAttrs = map[string]string{
+	"xml:lang":     "xml:lang",
+	"yahoo:uri":    "uri",
+	"time":         "time",
+	"copyright":    "copyright",
+	"refresh_rate": "refresh_rate",
+}
+
+// rootNamespaces are the default namespaces of the XML representation's
+// root elements.
+var rootNamespaces = map[string]string{
+	"fantasy_content": "http://fantasysports.yahooapis.com/fantasy/v2/base.rng",
+	"error":           "http://www.yahooapis.com/v1/base.rng",
+}
+
+// unmarshal decodes an XML or JSON response body into v.
+func unmarshal(body []byte, v interface{}) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return xml.Unmarshal(body, v)
+	}
+	doc, err := jsonToXML(trimmed)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(doc, v)
+}
+
+// jsonToXML rewrites a format=json response as the equivalent XML
+// document so that it can be decoded with the same struct tags.
+//
+// Yahoo!'s JSON is a mechanical translation of its XML with a few quirks:
+// resources are arrays whose elements are either objects of fields or
+// arrays of single-field objects, collections are objects keyed by "0",
+// "1", ... alongside a "count", and repeated elements are arrays of
+// single-key objects. All of these flatten to a sequence of child elements.
+func jsonToXML(body []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	var doc map[string]interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if len(doc) != 1 {
+		return nil, errors.New("yahooapi: JSON response must have a single root")
+	}
+
+	var buf bytes.Buffer
+	for name, v := range doc {
+		if err := writeElement(&buf, name, v, true); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// writeElement writes the element name whose JSON value is v.
+func writeElement(buf *bytes.Buffer, name string, v interface{}, root bool) error {
+	attrs := map[string]string{}
+	obj, _ := v.(map[string]interface{})
+	if root {
+		if ns, ok := rootNamespaces[name]; ok {
+			attrs["xmlns"] = ns
+		}
+		for k, attr := range rootAttrs {
+			if s, ok := obj[k]; ok {
+				attrs[attr] = scalar(s)
+			}
+		}
+	}
+	if count, ok := obj["count"]; ok && isCollection(obj) {
+		attrs["count"] = scalar(count)
+	}
+
+	buf.WriteString("<" + name)
+	for _, k := range sortedKeys(attrs) {
+		buf.WriteString(" " + k + `="`)
+		xml.EscapeText(buf, []byte(attrs[k]))
+		buf.WriteString(`"`)
+	}
+	buf.WriteString(">")
+	if err := writeContents(buf, v, root); err != nil {
+		return err
+	}
+	buf.WriteString("</" + name + ">")
+	return nil
+}
+
+// writeContents writes the children or text of the element whose JSON
+// value is v. When root is set the keys in rootAttrs are skipped, as they
+// have already been written as attributes.
+func writeContents(buf *bytes.Buffer, v interface{}, root bool) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		collection := isCollection(v)
+		for _, k := range sortedKeys(v) {
+			switch {
+			case collection && k == "count":
+			case root && rootAttrs[k] != "":
+			case isNumber(k):
+				if err := writeContents(buf, v[k], false); err != nil {
+					return err
+				}
+			default:
+				if err := writeElement(buf, k, v[k], false); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := writeContents(buf, item, false); err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		xml.EscapeText(buf, []byte(scalar(v)))
+	}
+	return nil
+}
+
+// scalar formats a JSON string, number or boolean as XML text. Booleans
+// follow Yahoo!'s "1"/"0" convention.
+func scalar(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// isCollection reports whether obj is a collection, i.e. has index keys.
+func isCollection(obj map[string]interface{}) bool {
+	for k := range obj {
+		if isNumber(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m with index keys first, in numeric
+// order, followed by the remaining keys in lexical order.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]interface{}:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Sort(byIndex(keys))
+	return keys
+}
+
+type byIndex []string
+
+func (s byIndex) Len() int      { return len(s) }
+func (s byIndex) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byIndex) Less(i, j int) bool {
+	a, aerr := strconv.Atoi(s[i])
+	b, berr := strconv.Atoi(s[j])
+	switch {
+	case aerr == nil && berr == nil:
+		return a < b
+	case aerr == nil || berr == nil:
+		return aerr == nil
+	}
+	return s[i] < s[j]
+}
@@ -0,0 +1,75 @@
+package yahooapi
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// The XML samples in testdata are copied from the documentation in
+// fantasysports.go; each has a hand written format=json equivalent.
+var jsonParitySamples = []string{
+	"game",
+	"game_weeks",
+	"league",
+	"league_draftresults",
+	"league_mlb_standings",
+	"league_player_stats",
+	"league_scoreboard",
+	"league_settings",
+	"league_standings",
+	"team",
+	"team_matchups",
+	"team_roster",
+	"transaction",
+	"transaction_pending_trade",
+	"users",
+}
+
+func TestJSONParity(t *testing.T) {
+	for _, name := range jsonParitySamples {
+		fromXML := decodeSample(t, name+".xml")
+		fromJSON := decodeSample(t, name+".json")
+		if !reflect.DeepEqual(fromXML, fromJSON) {
+			t.Errorf("%s: JSON decoded to\n%+v\nwant\n%+v", name, fromJSON, fromXML)
+		}
+	}
+}
+
+func TestJSONCollectionCount(t *testing.T) {
+	doc, err := jsonToXML([]byte(`{"teams":{"0":{"team":[[{"team_key":"223.l.431.t.1"}]]},"1":{"team":[[{"team_key":"223.l.431.t.2"}]]},"count":2}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<teams count="2"><team><team_key>223.l.431.t.1</team_key></team><team><team_key>223.l.431.t.2</team_key></team></teams>`
+	if string(doc) != want {
+		t.Errorf("got %s, want %s", doc, want)
+	}
+}
+
+func TestJSONAPIError(t *testing.T) {
+	var e APIError
+	body := `{"error":{"xml:lang":"en-us","yahoo:uri":"http:\/\/fantasysports.yahooapis.com\/fantasy\/v2\/league\/223.l.431x","description":"Invalid league key","detail":""}}`
+	if err := unmarshal([]byte(body), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Description != "Invalid league key" {
+		t.Errorf("Description = %q", e.Description)
+	}
+	if e.URI != "http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431x" {
+		t.Errorf("URI = %q", e.URI)
+	}
+}
+
+func decodeSample(t *testing.T, name string) *FantasyContent {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var content FantasyContent
+	if err := unmarshal(body, &content); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return &content
+}
@@ -0,0 +1,326 @@
+package yahooapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeyError is returned when a string is not a well formed resource key.
+type KeyError struct {
+	Kind string
+	Key  string
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("yahooapi: invalid %s key %q", e.Kind, e.Key)
+}
+
+// GameKey identifies a game, either by its game_id (e.g. 223) or by its
+// game_code (e.g. pnfl). A game_code refers to the current season of that
+// game; Yahoo! translates it to the corresponding game_id and always
+// returns game_ids in the keys of its responses.
+//
+// The zero GameKey is not valid.
+type GameKey struct {
+	s string
+}
+
+// ParseGameKey parses a game_id or game_code.
+func ParseGameKey(s string) (GameKey, error) {
+	if !isNumber(s) && !isCode(s) {
+		return GameKey{}, &KeyError{"game", s}
+	}
+	return GameKey{s}, nil
+}
+
+// String returns the key as Yahoo! expects it in a URI.
+func (k GameKey) String() string { return k.s }
+
+// IsCode reports whether k is a game_code rather than a game_id.
+func (k GameKey) IsCode() bool { return isCode(k.s) }
+
+// ID returns the game_id of k, or 0 if k is a game_code.
+func (k GameKey) ID() int {
+	id, _ := strconv.Atoi(k.s)
+	return id
+}
+
+// League returns the key of league id within game k.
+func (k GameKey) League(id int) LeagueKey { return LeagueKey{k, id} }
+
+// Player returns the key of player id within game k.
+func (k GameKey) Player(id int) PlayerKey { return PlayerKey{k, id} }
+
+// LeagueKey identifies a league, formatted as {game_key}.l.{league_id},
+// e.g. 223.l.431 or pnfl.l.431.
+type LeagueKey struct {
+	game GameKey
+	id   int
+}
+
+// ParseLeagueKey parses a league key.
+func ParseLeagueKey(s string) (LeagueKey, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return LeagueKey{}, &KeyError{"league", s}
+	}
+	k, ok := parseLeague(parts)
+	if !ok {
+		return LeagueKey{}, &KeyError{"league", s}
+	}
+	return k, nil
+}
+
+func parseLeague(parts []string) (LeagueKey, bool) {
+	game, err := ParseGameKey(parts[0])
+	if err != nil || parts[1] != "l" {
+		return LeagueKey{}, false
+	}
+	id, ok := parseID(parts[2])
+	return LeagueKey{game, id}, ok
+}
+
+// String returns the key as Yahoo! expects it in a URI.
+func (k LeagueKey) String() string {
+	if k.game.s == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.l.%d", k.game, k.id)
+}
+
+// Game returns the key of the game the league belongs to.
+func (k LeagueKey) Game() GameKey { return k.game }
+
+// ID returns the league_id of k.
+func (k LeagueKey) ID() int { return k.id }
+
+// Team returns the key of team id within league k.
+func (k LeagueKey) Team(id int) TeamKey { return TeamKey{k, id} }
+
+// TeamKey identifies a team, formatted as {league_key}.t.{team_id}, e.g.
+// 223.l.431.t.9.
+type TeamKey struct {
+	league LeagueKey
+	id     int
+}
+
+// ParseTeamKey parses a team key.
+func ParseTeamKey(s string) (TeamKey, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 5 || parts[3] != "t" {
+		return TeamKey{}, &KeyError{"team", s}
+	}
+	league, ok := parseLeague(parts[:3])
+	id, idOK := parseID(parts[4])
+	if !ok || !idOK {
+		return TeamKey{}, &KeyError{"team", s}
+	}
+	return TeamKey{league, id}, nil
+}
+
+// String returns the key as Yahoo! expects it in a URI.
+func (k TeamKey) String() string {
+	if k.league.game.s == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.t.%d", k.league, k.id)
+}
+
+// League returns the key of the league the team belongs to.
+func (k TeamKey) League() LeagueKey { return k.league }
+
+// ID returns the team_id of k.
+func (k TeamKey) ID() int { return k.id }
+
+// PlayerKey identifies a player within a game, formatted as
+// {game_key}.p.{player_id}, e.g. 223.p.5479.
+type PlayerKey struct {
+	game GameKey
+	id   int
+}
+
+// ParsePlayerKey parses a player key.
+func ParsePlayerKey(s string) (PlayerKey, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 || parts[1] != "p" {
+		return PlayerKey{}, &KeyError{"player", s}
+	}
+	game, err := ParseGameKey(parts[0])
+	id, ok := parseID(parts[2])
+	if err != nil || !ok {
+		return PlayerKey{}, &KeyError{"player", s}
+	}
+	return PlayerKey{game, id}, nil
+}
+
+// String returns the key as Yahoo! expects it in a URI.
+func (k PlayerKey) String() string {
+	if k.game.s == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.p.%d", k.game, k.id)
+}
+
+// Game returns the key of the game the player belongs to.
+func (k PlayerKey) Game() GameKey { return k.game }
+
+// ID returns the player_id of k.
+func (k PlayerKey) ID() int { return k.id }
+
+// TransactionKind distinguishes the three kinds of transaction key.
+type TransactionKind string
+
+const (
+	// CompletedTransaction keys look like 257.l.193.tr.2.
+	CompletedTransaction TransactionKind = "tr"
+	// WaiverClaim keys look like 257.l.193.w.c.2_6390.
+	WaiverClaim TransactionKind = "w.c"
+	// PendingTrade keys look like 257.l.193.pt.1.
+	PendingTrade TransactionKind = "pt"
+)
+
+// TransactionKey identifies a completed transaction, waiver claim or
+// pending trade within a league.
+type TransactionKey struct {
+	league LeagueKey
+	kind   TransactionKind
+	id     string
+}
+
+// ParseTransactionKey parses a transaction key of any kind.
+func ParseTransactionKey(s string) (TransactionKey, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 5 {
+		return TransactionKey{}, &KeyError{"transaction", s}
+	}
+	league, ok := parseLeague(parts[:3])
+	if !ok {
+		return TransactionKey{}, &KeyError{"transaction", s}
+	}
+
+	kind, id := TransactionKind(strings.Join(parts[3:len(parts)-1], ".")), parts[len(parts)-1]
+	switch kind {
+	case CompletedTransaction, PendingTrade:
+		ok = isNumber(id)
+	case WaiverClaim:
+		ok = isClaimID(id)
+	default:
+		ok = false
+	}
+	if !ok {
+		return TransactionKey{}, &KeyError{"transaction", s}
+	}
+	return TransactionKey{league, kind, id}, nil
+}
+
+// String returns the key as Yahoo! expects it in a URI.
+func (k TransactionKey) String() string {
+	if k.league.game.s == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s.%s", k.league, k.kind, k.id)
+}
+
+// League returns the key of the league the transaction belongs to.
+func (k TransactionKey) League() LeagueKey { return k.league }
+
+// Kind returns whether k is a completed transaction, waiver claim or
+// pending trade.
+func (k TransactionKey) Kind() TransactionKind { return k.kind }
+
+// ID returns the transaction_id, claim_id or pending_trade_id of k. Waiver
+// claim ids are not numeric, e.g. 2_6390.
+func (k TransactionKey) ID() string { return k.id }
+
+// parseGameKeys parses a comma separated list of game keys.
+func parseGameKeys(s string) ([]GameKey, error) {
+	var keys []GameKey
+	for _, part := range strings.Split(s, ",") {
+		k, err := ParseGameKey(part)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// parseLeagueKeys parses a comma separated list of league keys.
+func parseLeagueKeys(s string) ([]LeagueKey, error) {
+	var keys []LeagueKey
+	for _, part := range strings.Split(s, ",") {
+		k, err := ParseLeagueKey(part)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// parseTransactionKeys parses a comma separated list of transaction keys.
+func parseTransactionKeys(s string) ([]TransactionKey, error) {
+	var keys []TransactionKey
+	for _, part := range strings.Split(s, ",") {
+		k, err := ParseTransactionKey(part)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func gameKeyStrings(keys []GameKey) []string {
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k.String()
+	}
+	return s
+}
+
+func parseID(s string) (int, bool) {
+	if !isNumber(s) {
+		return 0, false
+	}
+	id, err := strconv.Atoi(s)
+	return id, err == nil
+}
+
+// isNumber reports whether s is a non-empty string of decimal digits.
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isCode reports whether s looks like a game_code such as nfl or pnfl.
+func isCode(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isClaimID reports whether s looks like a waiver claim id such as 2_6390.
+func isClaimID(s string) bool {
+	parts := strings.Split(s, "_")
+	for _, p := range parts {
+		if !isNumber(p) {
+			return false
+		}
+	}
+	return true
+}
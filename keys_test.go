@@ -0,0 +1,129 @@
+package yahooapi
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	type parser func(string) (fmt.Stringer, error)
+	game := func(s string) (fmt.Stringer, error) { return ParseGameKey(s) }
+	league := func(s string) (fmt.Stringer, error) { return ParseLeagueKey(s) }
+	team := func(s string) (fmt.Stringer, error) { return ParseTeamKey(s) }
+	player := func(s string) (fmt.Stringer, error) { return ParsePlayerKey(s) }
+	transaction := func(s string) (fmt.Stringer, error) { return ParseTransactionKey(s) }
+
+	tests := []struct {
+		kind  string
+		parse parser
+		in    string
+		want  string // "" when in must be rejected
+	}{
+		{"game", game, "223", "223"},
+		{"game", game, "pnfl", "pnfl"},
+		{"game", game, "", ""},
+		{"game", game, "NFL", ""},
+		{"game", game, "nfl2", ""},
+
+		{"league", league, "223.l.431", "223.l.431"},
+		{"league", league, "pnfl.l.431", "pnfl.l.431"},
+		{"league", league, "nfl.l.01", "nfl.l.1"},
+		{"league", league, "223.l", ""},
+		{"league", league, "223.t.431", ""},
+		{"league", league, "223.l.x", ""},
+		{"league", league, "223.l.-1", ""},
+
+		{"team", team, "223.l.431.t.9", "223.l.431.t.9"},
+		{"team", team, "nfl.l.01.t.09", "nfl.l.1.t.9"},
+		{"team", team, "223.l.431.p.9", ""},
+		{"team", team, "223.l.431.t", ""},
+		{"team", team, "223.x.431.t.9", ""},
+
+		{"player", player, "223.p.5479", "223.p.5479"},
+		{"player", player, "nfl.p.05479", "nfl.p.5479"},
+		{"player", player, "223.l.5479", ""},
+		{"player", player, "223.p.", ""},
+
+		{"transaction", transaction, "257.l.193.tr.2", "257.l.193.tr.2"},
+		{"transaction", transaction, "257.l.193.w.c.2_6390", "257.l.193.w.c.2_6390"},
+		{"transaction", transaction, "257.l.193.pt.1", "257.l.193.pt.1"},
+		{"transaction", transaction, "nfl.l.0193.tr.2", "nfl.l.193.tr.2"},
+		{"transaction", transaction, "257.l.193.tr.2_6390", ""},
+		{"transaction", transaction, "257.l.193.w.c.", ""},
+		{"transaction", transaction, "257.l.193.xx.1", ""},
+		{"transaction", transaction, "257.l.193.tr", ""},
+	}
+	for _, tt := range tests {
+		k, err := tt.parse(tt.in)
+		if tt.want == "" {
+			ke, ok := err.(*KeyError)
+			if !ok {
+				t.Errorf("%s %q: got %v, %v, want *KeyError", tt.kind, tt.in, k, err)
+				continue
+			}
+			if ke.Kind != tt.kind || ke.Key != tt.in {
+				t.Errorf("%s %q: got KeyError{%q, %q}", tt.kind, tt.in, ke.Kind, ke.Key)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: %v", tt.kind, tt.in, err)
+			continue
+		}
+		if got := k.String(); got != tt.want {
+			t.Errorf("%s %q: got %s, want %s", tt.kind, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionKeyKind(t *testing.T) {
+	tests := []struct {
+		in   string
+		kind TransactionKind
+		id   string
+	}{
+		{"257.l.193.tr.2", CompletedTransaction, "2"},
+		{"257.l.193.w.c.2_6390", WaiverClaim, "2_6390"},
+		{"257.l.193.pt.1", PendingTrade, "1"},
+	}
+	for _, tt := range tests {
+		k, err := ParseTransactionKey(tt.in)
+		if err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if k.Kind() != tt.kind || k.ID() != tt.id {
+			t.Errorf("%s: got kind %s id %s, want kind %s id %s", tt.in, k.Kind(), k.ID(), tt.kind, tt.id)
+		}
+		if got := k.League().String(); got != "257.l.193" {
+			t.Errorf("%s: got league %s, want 257.l.193", tt.in, got)
+		}
+	}
+}
+
+func TestKeyAccessors(t *testing.T) {
+	k, err := ParseTeamKey("pnfl.l.431.t.9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.ID() != 9 || k.League().ID() != 431 {
+		t.Errorf("got team %d league %d, want team 9 league 431", k.ID(), k.League().ID())
+	}
+	if g := k.League().Game(); !g.IsCode() || g.ID() != 0 {
+		t.Errorf("got game %s IsCode %v ID %d, want a game_code", g, g.IsCode(), g.ID())
+	}
+	if got := (GameKey{}).League(1).Team(2).String(); got != "" {
+		t.Errorf("zero game key: got %q, want empty", got)
+	}
+	if got := mustGameKey(t, "223").League(431).Team(9).String(); got != "223.l.431.t.9" {
+		t.Errorf("got %s, want 223.l.431.t.9", got)
+	}
+}
+
+func mustGameKey(t *testing.T, s string) GameKey {
+	k, err := ParseGameKey(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
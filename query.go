@@ -0,0 +1,366 @@
+package yahooapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Query builds a Fantasy Sports API resource path following the grammar
+// described in the package documentation:
+//
+//   /{resource}/{resource_key};{key}={value}/{collection};{key}={value}/...
+//
+// A Query starts at one of the entry points (Game, Games, League, Leagues,
+// Team, Teams, Player, Players, Transaction, Transactions or Users) and is
+// extended by chaining sub-resources and parameters, for example:
+//
+//   Users().UseLogin().Games().GameKeys("nfl").Leagues()
+//
+// yields "users;use_login=1/games;game_keys=nfl/leagues". Each sub-resource
+// is checked against the documented sub-resource tables as it is added; the
+// first violation is kept and returned by Path. Queries are immutable, so a
+// partially built Query may be shared and extended independently.
+type Query struct {
+	segments []segment
+	err      error
+}
+
+type segment struct {
+	name   string
+	key    string
+	params []param
+}
+
+type param struct {
+	name   string
+	values []string
+}
+
+// collections maps each collection to the resource it contains.
+var collections = map[string]string{
+	"games":        "game",
+	"leagues":      "league",
+	"teams":        "team",
+	"players":      "player",
+	"transactions": "transaction",
+	"users":        "user",
+	"matchups":     "matchup",
+}
+
+// subResources lists the documented sub-resources of each resource.
+var subResources = map[string][]string{
+	"game":         {"metadata", "leagues", "players", "game_weeks", "stat_categories", "position_types", "roster_positions"},
+	"league":       {"metadata", "settings", "standings", "scoreboard", "teams", "players", "draftresults", "transactions"},
+	"team":         {"metadata", "stats", "standings", "roster", "draftresults", "matchups", "players"},
+	"player":       {"metadata", "stats", "ownership", "percent_owned", "draft_analysis"},
+	"transaction":  {"metadata", "players"},
+	"user":         {"games", "teams"},
+	"standings":    {"teams"},
+	"scoreboard":   {"matchups"},
+	"matchup":      {"teams"},
+	"roster":       {"players"},
+	"draftresults": {"players"},
+}
+
+// collectionSubResources lists sub-resources that are only valid beneath a
+// collection, in addition to those of the collection's resource.
+var collectionSubResources = map[string][]string{
+	"games": {"teams"},
+}
+
+// keyParams maps the collections that accept a list of keys to the name of
+// that parameter.
+var keyParams = map[string]string{
+	"games":        "game_keys",
+	"leagues":      "league_keys",
+	"teams":        "team_keys",
+	"players":      "player_keys",
+	"transactions": "transaction_keys",
+}
+
+// Game starts a query for the game identified by gameKey.
+func Game(gameKey string) *Query { return resource("game", gameKey) }
+
+// League starts a query for the league identified by leagueKey.
+func League(leagueKey string) *Query { return resource("league", leagueKey) }
+
+// Team starts a query for the team identified by teamKey.
+func Team(teamKey string) *Query { return resource("team", teamKey) }
+
+// Player starts a query for the player identified by playerKey.
+func Player(playerKey string) *Query { return resource("player", playerKey) }
+
+// Transaction starts a query for the transaction identified by
+// transactionKey.
+func Transaction(transactionKey string) *Query { return resource("transaction", transactionKey) }
+
+// Games starts a query for the games collection.
+func Games() *Query { return collection("games") }
+
+// Leagues starts a query for the leagues collection.
+func Leagues() *Query { return collection("leagues") }
+
+// Teams starts a query for the teams collection.
+func Teams() *Query { return collection("teams") }
+
+// Players starts a query for the players collection.
+func Players() *Query { return collection("players") }
+
+// Transactions starts a query for the transactions collection.
+func Transactions() *Query { return collection("transactions") }
+
+// Users starts a query for the users collection. It is almost always
+// followed by UseLogin.
+func Users() *Query { return collection("users") }
+
+func resource(name, key string) *Query {
+	q := &Query{segments: []segment{{name: name, key: key}}}
+	if key == "" {
+		q.err = fmt.Errorf("yahooapi: %s requires a key", name)
+	}
+	return q
+}
+
+func collection(name string) *Query {
+	return &Query{segments: []segment{{name: name}}}
+}
+
+// clone returns a copy of q that can be modified without affecting q.
+func (q *Query) clone() *Query {
+	c := &Query{segments: make([]segment, len(q.segments)), err: q.err}
+	for i, s := range q.segments {
+		s.params = append([]param(nil), s.params...)
+		c.segments[i] = s
+	}
+	return c
+}
+
+func (q *Query) last() *segment {
+	return &q.segments[len(q.segments)-1]
+}
+
+// kind returns the resource type the last segment of q refers to.
+func (q *Query) kind() string {
+	name := q.last().name
+	if r, ok := collections[name]; ok {
+		return r
+	}
+	return name
+}
+
+// Sub appends the sub-resource name to q. It is an error for name not to
+// be a documented sub-resource of the preceding resource or collection.
+func (q *Query) Sub(name string) *Query {
+	c := q.clone()
+	if c.err == nil && !c.allows(name) {
+		c.err = fmt.Errorf("yahooapi: %s is not a sub-resource of %s", name, c.last().name)
+	}
+	c.segments = append(c.segments, segment{name: name})
+	return c
+}
+
+func (q *Query) allows(name string) bool {
+	return contains(subResources[q.kind()], name) ||
+		contains(collectionSubResources[q.last().name], name)
+}
+
+// Param adds the parameter name=values to the last segment of q. Multiple
+// values are joined with commas. Adding a parameter twice replaces its
+// earlier values.
+func (q *Query) Param(name string, values ...string) *Query {
+	c := q.clone()
+	s := c.last()
+	for i := range s.params {
+		if s.params[i].name == name {
+			s.params[i].values = values
+			return c
+		}
+	}
+	s.params = append(s.params, param{name: name, values: values})
+	return c
+}
+
+// Out asks for additional sub-resources of the last segment of q to be
+// included in the response, using the out parameter.
+func (q *Query) Out(names ...string) *Query {
+	c := q.clone()
+	for _, name := range names {
+		if c.err == nil && !c.allows(name) {
+			c.err = fmt.Errorf("yahooapi: %s is not a sub-resource of %s", name, c.last().name)
+		}
+	}
+	return c.Param("out", names...)
+}
+
+// keys sets the key filter of the collection at the end of q.
+func (q *Query) keys(collection string, keys []string) *Query {
+	if q.last().name != collection {
+		c := q.clone()
+		if c.err == nil {
+			c.err = fmt.Errorf("yahooapi: %s can only be applied to %s", keyParams[collection], collection)
+		}
+		return c
+	}
+	return q.Param(keyParams[collection], keys...)
+}
+
+// UseLogin restricts a users collection to the logged in user.
+func (q *Query) UseLogin() *Query {
+	if q.last().name != "users" {
+		c := q.clone()
+		if c.err == nil {
+			c.err = fmt.Errorf("yahooapi: use_login can only be applied to users")
+		}
+		return c
+	}
+	return q.Param("use_login", "1")
+}
+
+// GameKeys restricts a games collection to the given game keys.
+func (q *Query) GameKeys(keys ...string) *Query { return q.keys("games", keys) }
+
+// LeagueKeys restricts a leagues collection to the given league keys.
+func (q *Query) LeagueKeys(keys ...string) *Query { return q.keys("leagues", keys) }
+
+// TeamKeys restricts a teams collection to the given team keys.
+func (q *Query) TeamKeys(keys ...string) *Query { return q.keys("teams", keys) }
+
+// PlayerKeys restricts a players collection to the given player keys.
+func (q *Query) PlayerKeys(keys ...string) *Query { return q.keys("players", keys) }
+
+// TransactionKeys restricts a transactions collection to the given
+// transaction keys.
+func (q *Query) TransactionKeys(keys ...string) *Query { return q.keys("transactions", keys) }
+
+// Games appends the games collection to q.
+func (q *Query) Games() *Query { return q.Sub("games") }
+
+// Leagues appends the leagues collection to q.
+func (q *Query) Leagues() *Query { return q.Sub("leagues") }
+
+// Teams appends the teams collection to q.
+func (q *Query) Teams() *Query { return q.Sub("teams") }
+
+// Players appends the players collection to q.
+func (q *Query) Players() *Query { return q.Sub("players") }
+
+// Transactions appends the transactions collection to q.
+func (q *Query) Transactions() *Query { return q.Sub("transactions") }
+
+// Matchups appends the matchups collection to q.
+func (q *Query) Matchups() *Query { return q.Sub("matchups") }
+
+// Metadata appends the metadata sub-resource to q.
+func (q *Query) Metadata() *Query { return q.Sub("metadata") }
+
+// Settings appends the league settings sub-resource to q.
+func (q *Query) Settings() *Query { return q.Sub("settings") }
+
+// Standings appends the standings sub-resource to q.
+func (q *Query) Standings() *Query { return q.Sub("standings") }
+
+// Scoreboard appends the league scoreboard sub-resource to q.
+func (q *Query) Scoreboard() *Query { return q.Sub("scoreboard") }
+
+// Stats appends the stats sub-resource to q.
+func (q *Query) Stats() *Query { return q.Sub("stats") }
+
+// Roster appends the team roster sub-resource to q.
+func (q *Query) Roster() *Query { return q.Sub("roster") }
+
+// DraftResults appends the draftresults sub-resource to q.
+func (q *Query) DraftResults() *Query { return q.Sub("draftresults") }
+
+// subResource is a sub-resource to request through the out parameter,
+// along with the parameters it needs.
+type subResource struct {
+	name   string
+	params []param
+}
+
+// withSubResources asks for subs to be included in the response to q. As
+// the parameters of all the sub-resources are added to the last segment of
+// q, it is an error for two of them to need different values for the same
+// parameter, or for the same sub-resource to be requested with different
+// parameters, such as season and weekly stats.
+func (q *Query) withSubResources(subs []subResource) (*Query, error) {
+	if len(subs) == 0 {
+		return q, nil
+	}
+	var out []string
+	values := make(map[string]string)
+	subParams := make(map[string]string)
+	for _, sub := range subs {
+		if !contains(out, sub.name) {
+			out = append(out, sub.name)
+		}
+		for _, p := range sub.params {
+			v := strings.Join(p.values, ",")
+			if prev, ok := values[p.name]; ok && prev != v {
+				return nil, fmt.Errorf("yahooapi: conflicting values %q and %q for %s", prev, v, p.name)
+			}
+			values[p.name] = v
+			q = q.Param(p.name, p.values...)
+		}
+		ps := sub.paramString()
+		if prev, ok := subParams[sub.name]; ok && prev != ps {
+			return nil, fmt.Errorf("yahooapi: %s requested with conflicting parameters %q and %q", sub.name, prev, ps)
+		}
+		subParams[sub.name] = ps
+	}
+	return q.Out(out...), nil
+}
+
+// paramString returns the parameters of sub as they appear in a path.
+func (sub subResource) paramString() string {
+	var parts []string
+	for _, p := range sub.params {
+		parts = append(parts, p.name+"="+strings.Join(p.values, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Path returns the path described by q, relative to the API root, or the
+// first error encountered while building it.
+func (q *Query) Path() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	return q.String(), nil
+}
+
+// String returns the path described by q regardless of whether it is
+// valid.
+func (q *Query) String() string {
+	parts := make([]string, 0, len(q.segments))
+	for _, s := range q.segments {
+		part := s.name
+		if s.key != "" {
+			part += "/" + escape(s.key)
+		}
+		for _, p := range s.params {
+			values := make([]string, len(p.values))
+			for i, v := range p.values {
+				values[i] = escape(v)
+			}
+			part += ";" + p.name + "=" + strings.Join(values, ",")
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// escape encodes a key or parameter value for use in a path.
+func escape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
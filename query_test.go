@@ -0,0 +1,116 @@
+package yahooapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryPath(t *testing.T) {
+	tests := []struct {
+		q    *Query
+		want string
+	}{
+		{Game("nfl"), "game/nfl"},
+		{Users().UseLogin().Games().GameKeys("nfl", "mlb").Leagues(), "users;use_login=1/games;game_keys=nfl,mlb/leagues"},
+		{League("223.l.431").Players().Param("status", "FA").Param("start", "25"), "league/223.l.431/players;status=FA;start=25"},
+		{League("223.l.431").Players().Param("search", "de la rosa"), "league/223.l.431/players;search=de%20la%20rosa"},
+		{League("223.l.431").Players().Param("search", "a+b&c"), "league/223.l.431/players;search=a%2Bb%26c"},
+		{Team("223.l.431.t.1").Param("week", "1").Param("week", "2"), "team/223.l.431.t.1;week=2"},
+		{Team("223.l.431.t.1").Out("stats", "standings"), "team/223.l.431.t.1;out=stats,standings"},
+		{Team("223.l.431.t.1").Roster().Players(), "team/223.l.431.t.1/roster/players"},
+		{League("223.l.431").Scoreboard().Matchups().Teams(), "league/223.l.431/scoreboard/matchups/teams"},
+		{League("223.l.431").DraftResults().Players(), "league/223.l.431/draftresults/players"},
+		{Users().UseLogin().Games().Teams(), "users;use_login=1/games/teams"},
+		{Transactions().TransactionKeys("257.l.193.w.c.2_6390"), "transactions;transaction_keys=257.l.193.w.c.2_6390"},
+	}
+	for _, tt := range tests {
+		got, err := tt.q.Path()
+		if err != nil {
+			t.Errorf("%s: %v", tt.want, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		q    *Query
+		want string
+	}{
+		{Game(""), "game requires a key"},
+		{Game("nfl").Roster(), "roster is not a sub-resource of game"},
+		{Player("223.p.5479").Teams(), "teams is not a sub-resource of player"},
+		{League("223.l.431").Out("stats"), "stats is not a sub-resource of league"},
+		{Users().Leagues(), "leagues is not a sub-resource of users"},
+		{Games().TeamKeys("223.l.431.t.1"), "team_keys can only be applied to teams"},
+		{League("223.l.431").UseLogin(), "use_login can only be applied to users"},
+		// The first error is kept.
+		{Game("nfl").Roster().Settings(), "roster is not a sub-resource of game"},
+	}
+	for _, tt := range tests {
+		_, err := tt.q.Path()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got error %v, want %q", tt.q, err, tt.want)
+		}
+	}
+}
+
+func TestQueryImmutable(t *testing.T) {
+	base := League("223.l.431").Players()
+	a := base.Param("status", "FA")
+	b := base.Param("status", "W")
+	if got := base.String(); got != "league/223.l.431/players" {
+		t.Errorf("base = %s", got)
+	}
+	if got := a.String(); got != "league/223.l.431/players;status=FA" {
+		t.Errorf("a = %s", got)
+	}
+	if got := b.String(); got != "league/223.l.431/players;status=W" {
+		t.Errorf("b = %s", got)
+	}
+}
+
+func TestWithSubResources(t *testing.T) {
+	tests := []struct {
+		subs []subResource
+		want string
+		err  string
+	}{
+		{nil, "team/223.l.431.t.1", ""},
+		{[]subResource{{name: "stats"}, {name: "standings"}}, "team/223.l.431.t.1;out=stats,standings", ""},
+		{[]subResource{{name: "stats"}, {name: "stats"}}, "team/223.l.431.t.1;out=stats", ""},
+		{
+			[]subResource{subResource(WithTeamWeekStats(2)), subResource(WithTeamMatchups(1, 3, 6))},
+			"team/223.l.431.t.1;type=week;week=2;weeks=1,3,6;out=stats,matchups", "",
+		},
+		{
+			[]subResource{subResource(WithTeamWeekStats(2)), subResource(WithTeamWeekStats(2))},
+			"team/223.l.431.t.1;type=week;week=2;out=stats", "",
+		},
+		{[]subResource{subResource(WithTeamWeekStats(2)), subResource(WithTeamWeekStats(3))}, "", `conflicting values "2" and "3" for week`},
+		{[]subResource{subResource(WithTeamStats()), subResource(WithTeamWeekStats(2))}, "", `stats requested with conflicting parameters "" and "type=week;week=2"`},
+		{[]subResource{subResource(WithTeamWeekStats(0)), subResource(WithTeamStats())}, "", `stats requested with conflicting parameters "type=week;week=current" and ""`},
+		{[]subResource{{name: "ownership"}}, "", "ownership is not a sub-resource of team"},
+	}
+	for _, tt := range tests {
+		q, err := Team("223.l.431.t.1").withSubResources(tt.subs)
+		var got string
+		if err == nil {
+			got, err = q.Path()
+		}
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.want, err)
+		} else if got != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+}
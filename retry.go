@@ -0,0 +1,204 @@
+package yahooapi
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+// Defaults for the zero fields of a RetryTransport.
+const (
+	DefaultMaxRetries = 3
+	DefaultMinBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// RetryTransport is an http.RoundTripper that retries requests Yahoo!
+// throttled (status 999 or 429) or failed to serve (5xx), as well as
+// requests that failed to get a response at all. Waits grow exponentially
+// from MinBackoff to MaxBackoff with random jitter, unless the response
+// carries a Retry-After header, which is honored instead.
+//
+// Only GET, HEAD and OPTIONS requests are retried, as repeating a POST, PUT
+// or DELETE could repeat a transaction; other methods must be listed in
+// RetryMethods to be retried.
+//
+// NewTokenSourceClient and YahooConfig.Client put RetryTransport beneath
+// the OAuth2 transport. Token refreshes pass through it too, but they are
+// POSTs and so are not retried. Retries resend the Authorization header of
+// the first attempt; an expired token is reported as a 401, which is not
+// retried.
+type RetryTransport struct {
+	// Base is the transport requests are sent with. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// MaxRetries is the number of times a request is retried. If zero,
+	// DefaultMaxRetries is used; if negative, requests are not retried.
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff bound the wait before each retry. If zero,
+	// DefaultMinBackoff and DefaultMaxBackoff are used.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryMethods lists methods, such as "PUT", to retry in addition to
+	// GET, HEAD and OPTIONS.
+	RetryMethods []string
+
+	// OnRetry, if set, is called before each retry with the request, the
+	// number of the retry starting at 1, and the response or error of the
+	// attempt that failed. The response body has already been closed.
+	OnRetry func(req *http.Request, retry int, res *http.Response, err error)
+
+	retries int64
+}
+
+// Retries returns the number of retries t has made since it was created.
+func (t *RetryTransport) Retries() int64 {
+	return atomic.LoadInt64(&t.retries)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	max := t.MaxRetries
+	if max == 0 {
+		max = DefaultMaxRetries
+	}
+	if max < 0 || !t.retryable(req) {
+		return t.base().RoundTrip(req)
+	}
+
+	// A body can only be sent once, so keep a copy to send again.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for retry := 0; ; retry++ {
+		r := req
+		if req.Body != nil {
+			r = new(http.Request)
+			*r = *req
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		res, err := t.base().RoundTrip(r)
+		if retry == max || !shouldRetry(res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(retry)
+		if res != nil {
+			if d, ok := retryAfter(res); ok {
+				wait = d
+			}
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		atomic.AddInt64(&t.retries, 1)
+		if t.OnRetry != nil {
+			t.OnRetry(req, retry+1, res, err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Cancel:
+			timer.Stop()
+			return nil, context.Canceled
+		}
+	}
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *RetryTransport) retryable(req *http.Request) bool {
+	switch req.Method {
+	case "", "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return contains(t.RetryMethods, req.Method)
+}
+
+// backoff returns a random wait of between half and all of the exponential
+// backoff for the given retry.
+func (t *RetryTransport) backoff(retry int) time.Duration {
+	min, max := t.MinBackoff, t.MaxBackoff
+	if min == 0 {
+		min = DefaultMinBackoff
+	}
+	if max == 0 {
+		max = DefaultMaxBackoff
+	}
+	d := max
+	if retry < 32 && min<<uint(retry) < max {
+		d = min << uint(retry)
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// shouldRetry reports whether the outcome of an attempt is worth retrying.
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch {
+	case res.StatusCode == StatusRateLimited, res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case res.StatusCode >= 500 && res.StatusCode < 600:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header of res, which is either a number
+// of seconds or an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(time.Now())
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// withRetryTransport returns a copy of ctx whose oauth2.HTTPClient sends
+// requests through a new RetryTransport, layered over the transport of any
+// oauth2.HTTPClient already in ctx.
+func withRetryTransport(ctx context.Context) (context.Context, *RetryTransport) {
+	rt := &RetryTransport{}
+	c := &http.Client{}
+	if base, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && base != nil {
+		*c = *base
+		rt.Base = base.Transport
+	}
+	c.Transport = rt
+	return context.WithValue(ctx, oauth2.HTTPClient, c), rt
+}
@@ -0,0 +1,235 @@
+package yahooapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// statusServer responds to each request with the next of statuses, and
+// with the last once they run out. It records the bodies it receives.
+func statusServer(statuses []int, header http.Header) (*httptest.Server, *int32, *[]string) {
+	var n int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		i := int(atomic.AddInt32(&n, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(statuses[i])
+	}))
+	return srv, &n, &bodies
+}
+
+func fastRetries() *RetryTransport {
+	return &RetryTransport{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+func TestRetryStatuses(t *testing.T) {
+	tests := []struct {
+		statuses []int
+		want     int
+		attempts int32
+	}{
+		{[]int{999, 200}, 200, 2},
+		{[]int{429, 200}, 200, 2},
+		{[]int{500, 502, 200}, 200, 3},
+		{[]int{503, 503, 503, 504, 200}, 504, 4},
+		{[]int{404, 200}, 404, 1},
+		{[]int{401, 200}, 401, 1},
+		{[]int{200}, 200, 1},
+	}
+	for _, tt := range tests {
+		srv, n, _ := statusServer(tt.statuses, nil)
+		rt := fastRetries()
+		res, err := (&http.Client{Transport: rt}).Get(srv.URL)
+		srv.Close()
+		if err != nil {
+			t.Errorf("%v: %v", tt.statuses, err)
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode != tt.want || *n != tt.attempts {
+			t.Errorf("%v: got status %d after %d attempts, want %d after %d", tt.statuses, res.StatusCode, *n, tt.want, tt.attempts)
+		}
+		if rt.Retries() != int64(tt.attempts-1) {
+			t.Errorf("%v: got %d retries, want %d", tt.statuses, rt.Retries(), tt.attempts-1)
+		}
+	}
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	tests := []struct {
+		max      int
+		attempts int32
+	}{
+		{0, DefaultMaxRetries + 1},
+		{1, 2},
+		{5, 6},
+		{-1, 1},
+	}
+	for _, tt := range tests {
+		srv, n, _ := statusServer([]int{503}, nil)
+		rt := fastRetries()
+		rt.MaxRetries = tt.max
+		res, err := (&http.Client{Transport: rt}).Get(srv.URL)
+		srv.Close()
+		if err != nil {
+			t.Errorf("MaxRetries %d: %v", tt.max, err)
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode != 503 || *n != tt.attempts {
+			t.Errorf("MaxRetries %d: got status %d after %d attempts, want 503 after %d", tt.max, res.StatusCode, *n, tt.attempts)
+		}
+	}
+}
+
+func TestRetryTransportError(t *testing.T) {
+	var n int
+	rt := fastRetries()
+	rt.Base = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n++
+		if n < 3 {
+			return nil, errors.New("connection reset")
+		}
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	res, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != 200 || n != 3 {
+		t.Errorf("got status %d after %d attempts, want 200 after 3", res.StatusCode, n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	// The backoff alone would wait an hour; Retry-After cuts it short.
+	srv, n, _ := statusServer([]int{999, 200}, http.Header{"Retry-After": {"0"}})
+	defer srv.Close()
+	rt := &RetryTransport{MinBackoff: time.Hour, MaxBackoff: time.Hour}
+	start := time.Now()
+	res, err := (&http.Client{Transport: rt}).Get(srv.URL)
+	waited := time.Since(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != 200 || *n != 2 {
+		t.Errorf("got status %d after %d attempts, want 200 after 2", res.StatusCode, *n)
+	}
+	if waited > 10*time.Second {
+		t.Errorf("waited %v, want Retry-After: 0 honored", waited)
+	}
+
+	date := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	for _, v := range []string{"0", "120", date} {
+		d, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {v}}})
+		if !ok {
+			t.Errorf("Retry-After %q: not parsed", v)
+		}
+		if want := map[string]time.Duration{"0": 0, "120": 2 * time.Minute, date: 0}[v]; d != want {
+			t.Errorf("Retry-After %q: got %v, want %v", v, d, want)
+		}
+	}
+	if _, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {"soon"}}}); ok {
+		t.Errorf("Retry-After %q: parsed", "soon")
+	}
+}
+
+func TestRetryMethods(t *testing.T) {
+	tests := []struct {
+		method       string
+		retryMethods []string
+		attempts     int32
+	}{
+		{"POST", nil, 1},
+		{"PUT", nil, 1},
+		{"DELETE", nil, 1},
+		{"PUT", []string{"PUT"}, 2},
+		{"HEAD", nil, 2},
+	}
+	for _, tt := range tests {
+		srv, n, bodies := statusServer([]int{503, 200}, nil)
+		rt := fastRetries()
+		rt.RetryMethods = tt.retryMethods
+		req, _ := http.NewRequest(tt.method, srv.URL, strings.NewReader("<fantasy_content/>"))
+		res, err := (&http.Client{Transport: rt}).Do(req)
+		srv.Close()
+		if err != nil {
+			t.Errorf("%s: %v", tt.method, err)
+			continue
+		}
+		res.Body.Close()
+		if *n != tt.attempts {
+			t.Errorf("%s %v: got %d attempts, want %d", tt.method, tt.retryMethods, *n, tt.attempts)
+		}
+		if tt.method == "HEAD" {
+			continue
+		}
+		for i, b := range *bodies {
+			if b != "<fantasy_content/>" {
+				t.Errorf("%s attempt %d: got body %q", tt.method, i+1, b)
+			}
+		}
+	}
+}
+
+// TestRetryCanceled cancels a request during its backoff, as ctxhttp.Do does
+// through req.Cancel when its context is done.
+func TestRetryCanceled(t *testing.T) {
+	srv, n, _ := statusServer([]int{503}, nil)
+	defer srv.Close()
+	cancel := make(chan struct{})
+	rt := &RetryTransport{MinBackoff: time.Hour, MaxBackoff: time.Hour}
+	rt.OnRetry = func(*http.Request, int, *http.Response, error) { close(cancel) }
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Cancel = cancel
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := rt.RoundTrip(req)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("got %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("RoundTrip did not return when canceled during backoff")
+	}
+	if *n != 1 {
+		t.Errorf("got %d attempts, want 1", *n)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	rt := &RetryTransport{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for retry, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			if d := rt.backoff(retry); d < want/2 || d > want {
+				t.Errorf("retry %d: got %v, want between %v and %v", retry, d, want/2, want)
+			}
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -9,11 +9,14 @@ func (a *YahooConfig) RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("/yahoo/auth/", a.AuthYahoo)
 	r.HandleFunc("/yahoo/auth/callback", a.AuthYahooCallback)
 
-	// fantasy sports routes
+	// fantasy sports routes; keys are validated further by the handlers
 	r.HandleFunc("/yahoo/users/games", a.UserCollectionGamesHandler)
-	r.HandleFunc("/yahoo/users/game/{game_keys:[0-9]+}", a.UserCollectionAllHandler)
-	r.HandleFunc("/yahoo/users/game/{game_keys:[0-9]+}/leagues", a.UserCollectionLeaguesHandler)
-	r.HandleFunc("/yahoo/users/game/{game_keys:[0-9]+}/teams", a.UserCollectionTeamsHandler)
-  r.HandleFunc("/yahoo/users/leagues/{league_keys:[0-9a-zA-Z\\.]+}/scoreboard", a.LeagueScoreboardHandler)
-  r.HandleFunc("/yahoo/users/leagues/{league_keys:[0-9a-zA-Z\\.]+}/standings", a.LeagueStandingsHandler)
+	r.HandleFunc("/yahoo/users/game/{game_keys:[0-9a-z,]+}", a.UserCollectionAllHandler)
+	r.HandleFunc("/yahoo/users/game/{game_keys:[0-9a-z,]+}/leagues", a.UserCollectionLeaguesHandler)
+	r.HandleFunc("/yahoo/users/game/{game_keys:[0-9a-z,]+}/teams", a.UserCollectionTeamsHandler)
+	r.HandleFunc("/yahoo/users/leagues/{league_keys:[0-9a-z]+\\.l\\.[0-9]+}/scoreboard", a.LeagueScoreboardHandler)
+	r.HandleFunc("/yahoo/users/leagues/{league_keys:[0-9a-z]+\\.l\\.[0-9]+}/scoreboard/{week:[0-9]+}", a.LeagueScoreboardHandler)
+	r.HandleFunc("/yahoo/users/leagues/{league_keys:[0-9a-z]+\\.l\\.[0-9]+}/standings", a.LeagueStandingsHandler)
+	r.HandleFunc("/yahoo/users/leagues/{league_keys:[0-9a-z.,]+}/transactions", a.TransactionCollectionHandler)
+	r.HandleFunc("/yahoo/users/transactions/{transaction_keys:[0-9a-z._,]+}", a.TransactionCollectionHandler)
 }
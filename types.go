@@ -0,0 +1,108 @@
+package yahooapi
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Yahoo! encodes booleans as "1" and "0", which encoding/xml already
+// decodes into bool fields, and numbers as plain text, which it decodes
+// into int and float64 fields. Times need the help of the types below.
+
+// Timestamp is a time encoded by Yahoo! as seconds since the Unix epoch,
+// such as a league's league_update_timestamp. An empty value decodes to
+// the zero time.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Timestamp) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	t.Time = time.Unix(sec, 0).UTC()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The zero Timestamp is null and
+// others are RFC 3339 strings.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return t.Time.MarshalJSON()
+}
+
+// dateLayout is the format of the dates in Yahoo! responses.
+const dateLayout = "2006-01-02"
+
+// Date is a calendar day encoded by Yahoo! as YYYY-MM-DD, such as a
+// league's start_date. An empty value decodes to the zero time.
+type Date struct {
+	time.Time
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		d.Time = time.Time{}
+		return nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using Yahoo!'s format.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalJSON implements json.Marshaler using Yahoo!'s format. The zero
+// Date is null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// String returns d as YYYY-MM-DD, or "" for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateLayout)
+}
+
+// Number is a float that Yahoo! reports as "-" when it has no value, such
+// as the average pick of a player who was never drafted or the winning
+// percentage of a team before its first matchup. Such values decode to 0.
+type Number float64
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *Number) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" || s == "-" {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(f)
+	return nil
+}
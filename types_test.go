@@ -0,0 +1,59 @@
+package yahooapi
+
+import "testing"
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Number
+	}{
+		{"", 0},
+		{"-", 0},
+		{" - ", 0},
+		{".692", 0.692},
+		{"-.5", -0.5},
+		{"1682.33", 1682.33},
+	}
+	for _, tt := range tests {
+		var n Number
+		if err := n.UnmarshalText([]byte(tt.in)); err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.in, n, tt.want)
+		}
+	}
+	var n Number
+	if err := n.UnmarshalText([]byte("n/a")); err == nil {
+		t.Errorf("%q: got %v, want an error", "n/a", n)
+	}
+}
+
+// TestDecodeDailyLeague decodes a baseball league, whose edit_key is a date
+// rather than a week and whose standings have no percentages or points
+// before the season starts.
+func TestDecodeDailyLeague(t *testing.T) {
+	for _, name := range []string{"league_mlb_standings.xml", "league_mlb_standings.json"} {
+		league := decodeSample(t, name).League
+		if league == nil {
+			t.Fatalf("%s: no league", name)
+		}
+		if league.EditKey != "2011-07-23" {
+			t.Errorf("%s: got edit_key %q, want 2011-07-23", name, league.EditKey)
+		}
+		if got := league.StartDate.String(); got != "2011-03-31" {
+			t.Errorf("%s: got start_date %s, want 2011-03-31", name, got)
+		}
+		if len(league.Standings) != 2 {
+			t.Fatalf("%s: got %d teams, want 2", name, len(league.Standings))
+		}
+		standings := league.Standings[0].TeamStandings
+		if standings == nil || standings.OutcomeTotals == nil {
+			t.Fatalf("%s: missing team_standings", name)
+		}
+		if standings.Rank != 0 || standings.PointsFor != 0 || standings.OutcomeTotals.Percentage != 0 {
+			t.Errorf("%s: got %+v, want zero rank, points and percentage", name, standings)
+		}
+	}
+}